@@ -1,17 +1,22 @@
 package bereke_merchant
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/bsagat/bereke-merchant-api/internal/ratelimit"
 	"github.com/bsagat/bereke-merchant-api/models/core"
 	"github.com/bsagat/bereke-merchant-api/models/types"
+	"github.com/bsagat/bereke-merchant-api/ordernumber"
 )
 
 type method string
@@ -24,6 +29,10 @@ const (
 var (
 	testURL = "https://3dsec.berekebank.kz/payment/rest/"
 	prodURL = "https://securepayments.berekebank.kz/payment/rest/"
+
+	// Корень платёжных endpoint'ов, работающих вне /rest/ (Apple Pay, Google Pay)
+	testPaymentURL = "https://3dsec.berekebank.kz/payment/"
+	prodPaymentURL = "https://securepayments.berekebank.kz/payment/"
 )
 
 // API — основной интерфейс для работы с Bereke Merchant API.
@@ -33,7 +42,9 @@ var (
 // Методы разделены на группы:
 //   - Заказы (RegisterOrder, AuthOrder, GetOrderStatus...)
 //   - Операции с заказами (RefundOrder, DepositOrder, ReversalOrder, CancelOrder...)
-//   - Системные методы (Ping)
+//   - Платежи (PayWithApplePay, PayWithGooglePay, PayOrder, RegisterQR)
+//   - 3-D Secure (VerifyEnrollment, ContinueThreeDS2, FinishThreeDS)
+//   - Системные методы (Ping, HealthCheck)
 type API interface {
 	// --- Заказы ---
 
@@ -88,73 +99,158 @@ type API interface {
 	// CancelOrderByID — упрощённая отмена заказа по ID.
 	CancelOrderByID(ctx context.Context, orderID string) (core.Response, error)
 
+	// --- Платежи ---
+
+	// PayWithApplePay — оплата заказа платёжным токеном Apple Pay.
+	// Endpoint: applepay/payment.do
+	PayWithApplePay(ctx context.Context, req core.ApplePayRequest) (core.PaymentResponse, error)
+
+	// PayWithGooglePay — оплата заказа платёжным токеном Google Pay.
+	// Endpoint: google/payment.do
+	PayWithGooglePay(ctx context.Context, req core.GooglePayRequest) (core.PaymentResponse, error)
+
+	// PayOrder — оплата зарегистрированного заказа данными карты (только для PCI DSS мерчантов).
+	// Endpoint: paymentorder.do
+	PayOrder(ctx context.Context, req core.CardPaymentRequest) (core.PaymentResponse, error)
+
+	// RegisterQR — регистрация заказа с оплатой по динамическому QR-коду.
+	// Endpoint: register.do + QREndpoints.Dynamic
+	// Экспериментально: требует опции WithExperimentalQR.
+	RegisterQR(ctx context.Context, req core.QRRequest) (core.QRResponse, error)
+
+	// GetQRStatus — получение статуса оплаты по QR-коду.
+	// Endpoint: QREndpoints.Status
+	// Экспериментально: требует опции WithExperimentalQR.
+	GetQRStatus(ctx context.Context, req core.QRStatusRequest) (core.QRStatusResponse, error)
+
+	// --- 3-D Secure ---
+
+	// VerifyEnrollment — проверка вовлечённости карты в 3-D Secure.
+	// Endpoint: verifyEnrollment.do
+	VerifyEnrollment(ctx context.Context, req core.EnrollmentRequest) (core.EnrollmentResponse, error)
+
+	// ContinueThreeDS2 — передача данных 3DS Method и браузера клиента (3DS2).
+	// Endpoint: paymentorder.do
+	ContinueThreeDS2(ctx context.Context, req core.ThreeDS2Request) (core.PaymentResponse, error)
+
+	// FinishThreeDS — завершение 3DS2 после прохождения challenge.
+	// Endpoint: finishThreeDs.do
+	FinishThreeDS(ctx context.Context, req core.FinishThreeDSRequest) (core.PaymentResponse, error)
+
 	// --- Системное ---
 
 	// Ping — проверка доступности API (делает GET на базовый URL).
+	// Учётные данные не проверяются; для readiness-проб используйте HealthCheck.
 	Ping() error
+
+	// HealthCheck — проверка доступности шлюза, учётных данных и сертификата (см. health.go).
+	// Endpoint: getOrderStatusExtended.do
+	HealthCheck(ctx context.Context) (HealthStatus, error)
 }
 
 type api struct {
 	authType       types.Auth
 	credentials    url.Values
 	baseURL        string
+	paymentURL     string
 	mode           types.Mode
 	certPath       string
 	certPassphrase string
+
+	// Ограничения частоты и параллелизма запросов (см. options.go)
+	limiter          *ratelimit.Limiter
+	endpointLimiters map[string]*ratelimit.Limiter
+	inFlight         ratelimit.Semaphore
+
+	// Circuit breaker (см. breaker.go); nil — не используется
+	breaker *CircuitBreaker
+
+	// Приёмник метрик (см. metrics.go); nil — метрики не собираются
+	metrics MetricsRecorder
+
+	// Трассировка (см. tracing.go); nil — span'ы не создаются
+	tracer Tracer
+
+	// Генератор номеров заказов (см. options.go); nil — номер обязателен в запросе
+	orderNumbers ordernumber.Generator
+
+	// HTTP-клиент (см. options.go); nil — http.DefaultClient
+	httpClient *http.Client
+
+	// Endpoint'ы QR-кодов (см. qr.go); nil — методы QR-кодов отключены
+	qr *QREndpoints
 }
 
 // NewWithLogin — инициализация API с аутентификацией по логину/паролю.
-func NewWithLogin(login, password string, mode types.Mode) (API, error) {
+func NewWithLogin(login, password string, mode types.Mode, opts ...Option) (API, error) {
 	creds := url.Values{}
 	creds.Set("userName", login)
 	creds.Set("password", password)
-	return newAPI(mode, creds, types.AuthLogin, "", "")
+	return newAPI(mode, creds, types.AuthLogin, "", "", opts)
 }
 
 // NewWithToken — инициализация API с аутентификацией по токену.
-func NewWithToken(token string, mode types.Mode) (API, error) {
+func NewWithToken(token string, mode types.Mode, opts ...Option) (API, error) {
 	creds := url.Values{}
 	creds.Set("token", token)
-	return newAPI(mode, creds, types.AuthToken, "", "")
+	return newAPI(mode, creds, types.AuthToken, "", "", opts)
 }
 
 // NewWithCertificate — инициализация API с аутентификацией по сертификату (PKCS12).
-func NewWithCertificate(certPath, passphrase string, mode types.Mode) (API, error) {
-	return newAPI(mode, url.Values{}, types.AuthCertificate, certPath, passphrase)
+func NewWithCertificate(certPath, passphrase string, mode types.Mode, opts ...Option) (API, error) {
+	return newAPI(mode, url.Values{}, types.AuthCertificate, certPath, passphrase, opts)
 }
 
-func newAPI(mode types.Mode, creds url.Values, authType types.Auth, certPath, passphrase string) (API, error) {
-	var baseURL string
+func newAPI(mode types.Mode, creds url.Values, authType types.Auth, certPath, passphrase string, opts []Option) (API, error) {
+	var baseURL, paymentURL string
 	switch mode {
 	case types.TEST:
-		baseURL = testURL
+		baseURL, paymentURL = testURL, testPaymentURL
 	case types.PROD:
-		baseURL = prodURL
+		baseURL, paymentURL = prodURL, prodPaymentURL
 	default:
 		return nil, fmt.Errorf("invalid mode: %s", mode)
 	}
 
-	return &api{
+	a := &api{
 		authType:       authType,
 		credentials:    creds,
 		baseURL:        baseURL,
+		paymentURL:     paymentURL,
 		mode:           mode,
 		certPath:       certPath,
 		certPassphrase: passphrase,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a, nil
 }
 
 func (a *api) Ping() error {
+	return a.ping(context.Background())
+}
+
+// ping — проверка доступности шлюза с учётом ctx (используется и пробой circuit breaker).
+func (a *api) ping(ctx context.Context) error {
 	client := http.Client{
-		Timeout: 3 * time.Second,
+		Transport: a.client().Transport,
+		Timeout:   3 * time.Second,
 	}
 
-	resp, err := client.Get(a.baseURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL, nil)
 	if err != nil {
-		return fmt.Errorf("server is unreachable: %v", err)
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("server is unreachable: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("server is unavailable: %s", resp.Status)
+	}
 	return nil
 }
 
@@ -180,17 +276,7 @@ func (a *api) sendRequest(ctx context.Context, method method, path string, param
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	// Добавление параметров авторизации
-	query := url.Values{}
-	for key, vals := range a.credentials {
-		for _, val := range vals {
-			query.Add(key, val)
-		}
-	}
-	for key, vals := range params {
-		for _, val := range vals {
-			query.Add(key, val)
-		}
-	}
+	query := a.withCredentials(params)
 
 	// PROD-режим: подписываем или шифруем
 	if a.mode == types.PROD && a.authType == types.AuthCertificate {
@@ -203,16 +289,196 @@ func (a *api) sendRequest(ctx context.Context, method method, path string, param
 		req.URL.RawQuery = query.Encode()
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	return a.do(req, path, result, false, paramsAttributes(params))
+}
+
+// sendJSONRequest — отправка JSON-запроса к платёжным endpoint'ам шлюза,
+// которые расположены вне /rest/ (например, "applepay/payment.do")
+// и принимают тело запроса в формате JSON.
+//
+// ⚠️ В PROD-режиме с сертификатом тело запроса дополнительно подписывается.
+func (a *api) sendJSONRequest(ctx context.Context, path string, body interface{}, result interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		log.Printf("Error encoding request: %v", err)
+		return err
+	}
+
+	endpoint := a.paymentURL + path
+	req, err := http.NewRequestWithContext(ctx, string(POST), endpoint, bytes.NewReader(payload))
+	if err != nil {
+		log.Printf("Error creating request: %v", err)
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	if a.mode == types.PROD && a.authType == types.AuthCertificate {
+		if err := a.signAndSetHeaders(req, string(payload)); err != nil {
+			log.Printf("Error signing request: %v", err)
+			return err
+		}
+	}
+
+	return a.do(req, path, result, false, payloadAttributes(payload))
+}
+
+// merchantLogin — логин мерчанта из учётных данных клиента.
+// Используется endpoint'ами, которые требуют явного указания мерчанта (Apple Pay, Google Pay).
+func (a *api) merchantLogin() string {
+	return a.credentials.Get("userName")
+}
+
+// sendFormRequest — отправка POST-запроса с параметрами в теле (application/x-www-form-urlencoded).
+// Используется для запросов с карточными данными: параметры не попадают в URL,
+// а ошибки не логируются, чтобы PAN/CVC не оказались в журналах приложения.
+func (a *api) sendFormRequest(ctx context.Context, path string, params url.Values, result interface{}) error {
+	body := a.withCredentials(params).Encode()
+
+	endpoint := fmt.Sprintf("%s/%s", a.baseURL, path)
+	req, err := http.NewRequestWithContext(ctx, string(POST), endpoint, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "*/*")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if a.mode == types.PROD && a.authType == types.AuthCertificate {
+		if err := a.signAndSetHeaders(req, body); err != nil {
+			return err
+		}
+	}
+
+	return a.do(req, path, result, true, paramsAttributes(params))
+}
+
+// do — выполнение подготовленного запроса: ожидание лимитов (options.go),
+// проверка circuit breaker (breaker.go), отправка, декодирование JSON-ответа в result,
+// запись метрик (metrics.go) и span'а трассировки (tracing.go).
+// quiet = true отключает логирование ошибок (запросы с карточными данными).
+func (a *api) do(req *http.Request, path string, result interface{}, quiet bool, attrs SpanAttributes) (err error) {
+	ctx, span := a.startSpan(req.Context(), path)
+	if span != nil {
+		req = req.WithContext(ctx)
+		defer func() { span.End(attrs, err) }()
+	}
+
+	release, err := a.acquire(ctx, path)
 	if err != nil {
-		log.Printf("Error making request: %v", err)
+		return err
+	}
+	defer release()
+
+	start := time.Now()
+	if err := a.breaker.allow(ctx, a.ping); err != nil {
+		a.recordMetrics(ctx, RequestMetrics{Endpoint: path, Err: err})
+		return err
+	}
+
+	resp, err := a.client().Do(req)
+	if err != nil {
+		a.breaker.record(err, 0, 0, 0)
+		a.recordMetrics(ctx, RequestMetrics{Endpoint: path, Duration: time.Since(start), Err: err})
+		if !quiet {
+			log.Printf("Error making request: %v", err)
+		}
 		return err
 	}
 	defer resp.Body.Close()
 
-	if result != nil {
-		return json.NewDecoder(resp.Body).Decode(result)
+	err = decodeResponse(resp, path, result)
+
+	errorCode, actionCode := gatewayErrorCode(result), gatewayActionCode(result)
+	attrs.HTTPStatus, attrs.ErrorCode = resp.StatusCode, errorCode
+	if orderID := gatewayOrderID(result); orderID != "" {
+		attrs.OrderID = orderID
+	}
+
+	// Неразборчивый ответ (HTML-страница прокси и т.п.) — признак деградации шлюза
+	var failure error
+	if errors.Is(err, ErrUnexpectedResponse) {
+		failure = err
 	}
-	_, err = io.Copy(io.Discard, resp.Body)
+	a.breaker.record(failure, resp.StatusCode, errorCode, breakerActionCode(path, actionCode))
+	a.recordMetrics(ctx, RequestMetrics{
+		Endpoint:   path,
+		Duration:   time.Since(start),
+		HTTPStatus: resp.StatusCode,
+		ErrorCode:  errorCode,
+		ActionCode: actionCode,
+		Err:        err,
+	})
 	return err
 }
+
+// maxResponseSize — максимальный размер тела ответа шлюза.
+const maxResponseSize = 10 << 20
+
+// decodeResponse — проверка HTTP-статуса, чтение и декодирование JSON-ответа в result.
+//   - статус вне 2xx с JSON или пустым телом — *HTTPError с началом тела ответа;
+//   - статус вне 2xx с телом не в JSON (HTML-страница балансировщика) — *UnexpectedResponseError,
+//     оборачивающая *HTTPError: срабатывают и errors.Is(err, ErrUnexpectedResponse), и errors.As с *HTTPError;
+//   - пустое тело или не JSON (HTML-страница ошибки, обрезанный ответ) — *UnexpectedResponseError.
+func decodeResponse(resp *http.Response, path string, result interface{}) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		httpErr := &HTTPError{
+			Endpoint:   path,
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+		}
+		trimmed := bytes.TrimSpace(body)
+		if len(trimmed) == 0 || json.Valid(trimmed) {
+			httpErr.Body = bodySnippet(body)
+			return httpErr
+		}
+		return &UnexpectedResponseError{
+			Endpoint:    path,
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        bodySnippet(body),
+			Err:         httpErr,
+		}
+	}
+	if result == nil {
+		return nil
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		err = ErrEmptyResponse
+	} else {
+		err = json.Unmarshal(body, result)
+	}
+	if err == nil {
+		return nil
+	}
+	return &UnexpectedResponseError{
+		Endpoint:    path,
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        bodySnippet(body),
+		Err:         err,
+	}
+}
+
+// withCredentials — объединяет параметры запроса с параметрами авторизации.
+func (a *api) withCredentials(params url.Values) url.Values {
+	query := url.Values{}
+	for key, vals := range a.credentials {
+		for _, val := range vals {
+			query.Add(key, val)
+		}
+	}
+	for key, vals := range params {
+		for _, val := range vals {
+			query.Add(key, val)
+		}
+	}
+	return query
+}
@@ -0,0 +1,234 @@
+// Package batch — массовое выполнение возвратов, реверсов и отмен заказов
+// с ограничением параллелизма и частоты запросов и журналом прогресса,
+// который позволяет безопасно продолжить прерванный запуск.
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+	money "github.com/bsagat/bereke-merchant-api/currency"
+	"github.com/bsagat/bereke-merchant-api/internal/ratelimit"
+	"github.com/bsagat/bereke-merchant-api/models/code"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+)
+
+// Operation — тип операции над заказом.
+type Operation string
+
+const (
+	Refund  Operation = "refund"  // RefundOrder — возврат средств
+	Reverse Operation = "reverse" // ReversalOrder — реверс авторизации
+	Cancel  Operation = "cancel"  // CancelOrder — отмена заказа
+)
+
+func (o Operation) valid() bool {
+	return o == Refund || o == Reverse || o == Cancel
+}
+
+// Status — итог обработки строки пакета.
+type Status string
+
+const (
+	StatusDone    Status = "done"    // операция выполнена
+	StatusFailed  Status = "failed"  // шлюз отклонил операцию или запрос не был отправлен
+	StatusSkipped Status = "skipped" // операция уже выполнена в предыдущем запуске или дублирует строку выше
+	StatusUnknown Status = "unknown" // исход неизвестен — требуется ручная проверка статуса заказа
+)
+
+// Config — параметры пакетной обработки.
+type Config struct {
+	// Операция по умолчанию (если не указана в строке)
+	Operation Operation
+
+	// Максимальное количество одновременных запросов (по умолчанию 4)
+	Concurrency int
+
+	// Максимальное количество запросов в секунду (0 — без ограничения)
+	RatePerSecond float64
+
+	// Журнал прогресса. Если nil — запуск нельзя будет продолжить после падения
+	Journal *Journal
+
+	// true — повторно отправлять операции с неизвестным исходом из прошлого запуска.
+	// Используйте только после ручной проверки, что деньги по ним не двигались
+	RetryUnknown bool
+}
+
+// Result — результат обработки одной строки.
+type Result struct {
+	Item
+	Status       Status         `json:"status"`
+	ErrorCode    code.ErrorCode `json:"error_code,omitempty"`
+	ErrorMessage string         `json:"error_message,omitempty"`
+}
+
+// Processor — исполнитель пакетных операций поверх API клиента.
+type Processor struct {
+	api bereke.API
+	cfg Config
+}
+
+// New — создание исполнителя пакета.
+func New(api bereke.API, cfg Config) *Processor {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+	return &Processor{api: api, cfg: cfg}
+}
+
+// Run — выполняет операции пакета и возвращает отчёт (в порядке входных строк).
+// При отмене ctx необработанные строки получают статус failed и могут быть
+// выполнены повторным запуском с тем же журналом.
+// Срез items не изменяется: операция по умолчанию подставляется в копию строк.
+func (p *Processor) Run(ctx context.Context, items []Item) (Report, error) {
+	items = append([]Item(nil), items...)
+	for i := range items {
+		if items[i].Operation == "" {
+			items[i].Operation = p.cfg.Operation
+		}
+		if !items[i].Operation.valid() {
+			return Report{}, fmt.Errorf("item %d (%s): operation is not set", i+1, items[i].OrderID)
+		}
+	}
+
+	var limiter *ratelimit.Limiter
+	if p.cfg.RatePerSecond > 0 {
+		limiter = ratelimit.NewLimiter(p.cfg.RatePerSecond, 1)
+	}
+
+	results := make([]Result, len(items))
+	seen := map[string]bool{}
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < p.cfg.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = p.process(ctx, items[i], limiter)
+			}
+		}()
+	}
+
+	for i, item := range items {
+		key := item.key()
+		if seen[key] {
+			results[i] = Result{Item: item, Status: StatusSkipped, ErrorMessage: "duplicate of a previous line"}
+			continue
+		}
+		seen[key] = true
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return newReport(results), nil
+}
+
+func (p *Processor) process(ctx context.Context, item Item, limiter *ratelimit.Limiter) Result {
+	result := Result{Item: item}
+	key := item.key()
+
+	// Некорректная строка не попадает в журнал: запрос по ней не отправлялся
+	if err := item.validate(); err != nil {
+		result.Status = StatusFailed
+		result.ErrorMessage = err.Error()
+		return result
+	}
+
+	if p.cfg.Journal != nil {
+		if entry, ok := p.cfg.Journal.lookup(key); ok {
+			switch {
+			case entry.State == stateDone:
+				result.Status = StatusSkipped
+				result.ErrorMessage = "already done in a previous run"
+				return result
+			case (entry.State == stateStarted || entry.State == stateUnknown) && !p.cfg.RetryUnknown:
+				result.Status = StatusUnknown
+				result.ErrorMessage = "outcome of a previous run is unknown: verify order status manually"
+				return result
+			}
+		}
+	}
+
+	if limiter != nil {
+		if err := limiter.Wait(ctx); err != nil {
+			result.Status = StatusFailed
+			result.ErrorMessage = err.Error()
+			return result
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		result.Status = StatusFailed
+		result.ErrorMessage = err.Error()
+		return result
+	}
+
+	if err := p.journal(journalEntry{Key: key, State: stateStarted}); err != nil {
+		result.Status = StatusFailed
+		result.ErrorMessage = fmt.Sprintf("journal: %v", err)
+		return result
+	}
+
+	resp, err := p.execute(ctx, item)
+	switch {
+	case err != nil:
+		// Запрос мог дойти до шлюза — исход неизвестен
+		result.Status = StatusUnknown
+		result.ErrorMessage = err.Error()
+	case resp.ErrorCode != 0:
+		result.Status = StatusFailed
+		result.ErrorCode = resp.ErrorCode
+		result.ErrorMessage = resp.ErrorMessage
+	default:
+		result.Status = StatusDone
+	}
+
+	entry := journalEntry{Key: key, ErrorCode: result.ErrorCode, ErrorMessage: result.ErrorMessage}
+	switch result.Status {
+	case StatusDone:
+		entry.State = stateDone
+	case StatusFailed:
+		entry.State = stateFailed
+	default:
+		entry.State = stateUnknown
+	}
+	if err := p.journal(entry); err != nil && result.Status == StatusDone {
+		result.ErrorMessage = fmt.Sprintf("done, but journal write failed: %v", err)
+	}
+	return result
+}
+
+func (p *Processor) execute(ctx context.Context, item Item) (core.Response, error) {
+	currency := item.Currency
+	if currency == 0 {
+		currency = money.ToNumeric(money.KZT)
+	}
+
+	switch item.Operation {
+	case Refund:
+		return p.api.RefundOrder(ctx, core.RefundOrderRequest{OrderID: item.OrderID, Amount: item.Amount, Currency: currency})
+	case Reverse:
+		return p.api.ReversalOrder(ctx, core.ReversalOrderRequest{OrderID: item.OrderID, Amount: item.Amount, Currency: currency})
+	case Cancel:
+		return p.api.CancelOrder(ctx, core.CancelOrderRequest{OrderID: item.OrderID})
+	default:
+		return core.Response{}, fmt.Errorf("unknown operation %q", item.Operation)
+	}
+}
+
+func (p *Processor) journal(entry journalEntry) error {
+	if p.cfg.Journal == nil {
+		return nil
+	}
+	return p.cfg.Journal.record(entry)
+}
+
+// key — ключ операции в журнале: операция, заказ и сумма в минорных единицах.
+func (i Item) key() string {
+	return fmt.Sprintf("%s:%s:%d", i.Operation, i.OrderID, money.ToMinorUnit(i.Amount, i.Currency))
+}
@@ -0,0 +1,156 @@
+package batch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+)
+
+type fakeAPI struct {
+	bereke.API
+	refunds atomic.Int32
+}
+
+func (f *fakeAPI) RefundOrder(context.Context, core.RefundOrderRequest) (core.Response, error) {
+	f.refunds.Add(1)
+	return core.Response{}, nil
+}
+
+func TestRunInvalidItemIsNotJournaled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	journal, err := OpenJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api := &fakeAPI{}
+	items := []Item{
+		{OrderID: "order-1", Amount: 100},
+		{OrderID: "order-2"}, // операция по умолчанию — refund, сумма не указана
+	}
+	report, err := New(api, Config{Operation: Refund, Journal: journal}).Run(context.Background(), items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	journal.Close()
+
+	if got := report.Results[0].Status; got != StatusDone {
+		t.Errorf("item 1: status = %s, want %s", got, StatusDone)
+	}
+	if got := report.Results[1].Status; got != StatusFailed {
+		t.Errorf("item 2: status = %s, want %s", got, StatusFailed)
+	}
+	if got := api.refunds.Load(); got != 1 {
+		t.Errorf("refunds sent = %d, want 1", got)
+	}
+
+	journal, err = OpenJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Close()
+	if _, ok := journal.lookup(report.Results[1].key()); ok {
+		t.Error("invalid item was written to the journal")
+	}
+	if entry, ok := journal.lookup(report.Results[0].key()); !ok || entry.State != stateDone {
+		t.Errorf("item 1 journal entry = %+v, want state %s", entry, stateDone)
+	}
+}
+
+func TestOpenJournalTornLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	content := `{"key":"refund:order-1:10000","state":"done","time":"2026-01-01T00:00:00Z"}` + "\n" + `{"key":"refund:ord`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	journal, err := OpenJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := journal.record(journalEntry{Key: "refund:order-2:500", State: stateStarted}); err != nil {
+		t.Fatal(err)
+	}
+	journal.Close()
+
+	journal, err = OpenJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Close()
+	for _, key := range []string{"refund:order-1:10000", "refund:order-2:500"} {
+		if _, ok := journal.lookup(key); !ok {
+			t.Errorf("entry %s is lost after reopening", key)
+		}
+	}
+}
+
+func TestRunDoesNotModifyItems(t *testing.T) {
+	items := []Item{{OrderID: "order-1", Amount: 100}}
+	report, err := New(&fakeAPI{}, Config{Operation: Refund}).Run(context.Background(), items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items[0].Operation != "" {
+		t.Errorf("caller's item operation = %q, want it unchanged", items[0].Operation)
+	}
+	if got := report.Results[0].Operation; got != Refund {
+		t.Errorf("reported operation = %q, want %q", got, Refund)
+	}
+}
+
+func TestReadCurrency(t *testing.T) {
+	tests := []struct {
+		name  string
+		read  func() ([]Item, error)
+		want  int
+		isErr bool
+	}{
+		{"csv alpha", csvItem("kzt"), 398, false},
+		{"csv numeric", csvItem("840"), 840, false},
+		{"csv unknown", csvItem("XXX"), 0, true},
+		{"jsonl alpha", jsonlItem(`"KZT"`), 398, false},
+		{"jsonl numeric string", jsonlItem(`"840"`), 840, false},
+		{"jsonl number", jsonlItem(`398`), 398, false},
+		{"jsonl zero", jsonlItem(`0`), 0, false},
+		{"jsonl null", jsonlItem(`null`), 0, false},
+		{"jsonl unknown", jsonlItem(`"XXX"`), 0, true},
+		{"jsonl invalid", jsonlItem(`true`), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := tt.read()
+			if tt.isErr {
+				if err == nil {
+					t.Fatalf("items = %+v, want error", items)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 1 || items[0].Currency != tt.want || items[0].OrderID != "order-1" || items[0].Amount != 10.5 {
+				t.Errorf("items = %+v, want order-1 10.5 in currency %d", items, tt.want)
+			}
+		})
+	}
+}
+
+func csvItem(currency string) func() ([]Item, error) {
+	return func() ([]Item, error) {
+		return ReadCSV(strings.NewReader("order_id,amount,currency\norder-1,10.5," + currency + "\n"))
+	}
+}
+
+func jsonlItem(currency string) func() ([]Item, error) {
+	return func() ([]Item, error) {
+		return ReadJSONL(strings.NewReader(`{"order_id":"order-1","amount":10.5,"currency":` + currency + "}\n"))
+	}
+}
@@ -0,0 +1,174 @@
+package batch
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	money "github.com/bsagat/bereke-merchant-api/currency"
+)
+
+// Item — одна операция пакета.
+type Item struct {
+	OrderID  string  `json:"order_id"` // ID заказа в платёжном шлюзе
+	Amount   float64 `json:"amount"`   // Сумма в основных единицах валюты (0 — вся сумма, кроме refund)
+	Currency int     `json:"currency"` // Код валюты ISO 4217 (0 — KZT)
+
+	// Операция для этой строки. Если не указана — используется Config.Operation
+	Operation Operation `json:"operation,omitempty"`
+}
+
+// ReadFile — чтение операций из файла; формат определяется по расширению (.csv или .jsonl).
+func ReadFile(path string) ([]Item, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ReadCSV(f)
+	case ".jsonl", ".ndjson":
+		return ReadJSONL(f)
+	default:
+		return nil, fmt.Errorf("unsupported batch file format: %s (expected .csv or .jsonl)", path)
+	}
+}
+
+// ReadCSV — чтение операций из CSV с заголовком.
+// Обязательная колонка: order_id. Необязательные: amount, currency (KZT или 398), operation.
+func ReadCSV(r io.Reader) ([]Item, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["order_id"]; !ok {
+		return nil, errors.New("CSV header must contain order_id column")
+	}
+
+	column := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var items []Item
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		item := Item{
+			OrderID:   column(record, "order_id"),
+			Operation: Operation(strings.ToLower(column(record, "operation"))),
+		}
+		if amount := column(record, "amount"); amount != "" {
+			if item.Amount, err = strconv.ParseFloat(amount, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid amount %q", line, amount)
+			}
+		}
+		if currency := column(record, "currency"); currency != "" {
+			if item.Currency, err = parseCurrency(currency); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		if err := item.validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// ReadJSONL — чтение операций из JSON Lines (один объект Item на строку).
+// Валюта, как и в CSV, указывается кодом ISO 4217 в виде строки или числа: "KZT", "398" или 398.
+func ReadJSONL(r io.Reader) ([]Item, error) {
+	scanner := bufio.NewScanner(r)
+
+	var items []Item
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var record struct {
+			Item
+			Currency json.RawMessage `json:"currency"`
+		}
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		item := record.Item
+		if currency := jsonCurrency(record.Currency); currency != "" {
+			var err error
+			if item.Currency, err = parseCurrency(currency); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		if err := item.validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		items = append(items, item)
+	}
+	return items, scanner.Err()
+}
+
+// jsonCurrency — значение поля currency из JSON: строка без кавычек или число как есть (0 — не указана).
+func jsonCurrency(raw json.RawMessage) string {
+	var value string
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return strings.TrimSpace(value)
+	}
+	if string(raw) == "null" || string(raw) == "0" {
+		return ""
+	}
+	return string(raw)
+}
+
+// parseCurrency — числовой код валюты по буквенному ("KZT") или числовому ("398") коду ISO 4217.
+func parseCurrency(value string) (int, error) {
+	currency := money.ToNumeric(strings.ToUpper(money.FromString(value)))
+	if currency == 0 {
+		return 0, fmt.Errorf("unsupported currency %q", value)
+	}
+	return currency, nil
+}
+
+// validate — проверка строки. Вызывается при чтении входного файла и повторно
+// перед записью в журнал, когда операция по умолчанию уже подставлена.
+func (i Item) validate() error {
+	if i.OrderID == "" {
+		return errors.New("order_id is empty")
+	}
+	if i.Amount < 0 {
+		return errors.New("amount is negative")
+	}
+	if i.Operation != "" && !i.Operation.valid() {
+		return fmt.Errorf("unknown operation %q", i.Operation)
+	}
+	if i.Operation == Refund && i.Amount <= 0 {
+		return errors.New("refund amount must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package batch
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/internal/jsonl"
+	"github.com/bsagat/bereke-merchant-api/models/code"
+)
+
+// state — состояние операции в журнале.
+type state string
+
+const (
+	stateStarted state = "started" // запрос отправлен, результат ещё не получен
+	stateDone    state = "done"    // шлюз подтвердил операцию
+	stateFailed  state = "failed"  // шлюз отклонил операцию (деньги не двигались)
+	stateUnknown state = "unknown" // результат неизвестен (сетевая ошибка, таймаут)
+)
+
+type journalEntry struct {
+	Key          string         `json:"key"`
+	State        state          `json:"state"`
+	ErrorCode    code.ErrorCode `json:"error_code,omitempty"`
+	ErrorMessage string         `json:"error_message,omitempty"`
+	Time         time.Time      `json:"time"`
+}
+
+// Journal — журнал прогресса пакета (append-only JSON Lines).
+// Каждая запись сбрасывается на диск до и после отправки запроса, поэтому
+// после падения процесса повторный запуск с тем же журналом не повторит
+// уже выполненные операции, а операции с неизвестным исходом не будут
+// отправлены повторно без явного разрешения (Config.RetryUnknown).
+type Journal struct {
+	mu     sync.Mutex
+	file   *jsonl.File
+	states map[string]journalEntry
+}
+
+// OpenJournal — открывает (или создаёт) журнал и восстанавливает состояние из него.
+// Обрезанная при падении последняя строка удаляется из файла.
+func OpenJournal(path string) (*Journal, error) {
+	j := &Journal{states: map[string]journalEntry{}}
+
+	file, err := jsonl.Open(path, func(entry journalEntry) {
+		j.states[entry.Key] = entry
+	})
+	if err != nil {
+		return nil, err
+	}
+	j.file = file
+	return j, nil
+}
+
+// Close — закрывает файл журнала.
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
+
+func (j *Journal) lookup(key string) (journalEntry, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entry, ok := j.states[key]
+	return entry, ok
+}
+
+func (j *Journal) record(entry journalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry.Time = time.Now().UTC()
+	if err := j.file.Append(entry); err != nil {
+		return err
+	}
+
+	j.states[entry.Key] = entry
+	return nil
+}
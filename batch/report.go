@@ -0,0 +1,68 @@
+package batch
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// Report — итоговый отчёт о выполнении пакета.
+type Report struct {
+	Results []Result `json:"results"`
+
+	Done    int `json:"done"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	Unknown int `json:"unknown"`
+}
+
+func newReport(results []Result) Report {
+	report := Report{Results: results}
+	for _, r := range results {
+		switch r.Status {
+		case StatusDone:
+			report.Done++
+		case StatusFailed:
+			report.Failed++
+		case StatusSkipped:
+			report.Skipped++
+		case StatusUnknown:
+			report.Unknown++
+		}
+	}
+	return report
+}
+
+// WriteCSV — запись отчёта в CSV (по строке на операцию).
+func (r Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"order_id", "operation", "amount", "currency", "status", "error_code", "error_message"}); err != nil {
+		return err
+	}
+
+	for _, res := range r.Results {
+		record := []string{
+			res.OrderID,
+			string(res.Operation),
+			strconv.FormatFloat(res.Amount, 'f', -1, 64),
+			strconv.Itoa(res.Currency),
+			string(res.Status),
+			strconv.Itoa(int(res.ErrorCode)),
+			res.ErrorMessage,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSON — запись отчёта в JSON.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
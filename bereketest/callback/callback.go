@@ -0,0 +1,183 @@
+// Package callback — симулятор колбэков платёжного шлюза для локальной проверки
+// обработчика уведомлений без банка.
+//
+// Колбэк формируется и подписывается так же, как это делает шлюз: параметры mdOrder,
+// orderNumber, operation и status, контрольная сумма checksum по строке
+// "имя;значение;" отсортированных параметров — симметричная (HMAC-SHA256 с секретом мерчанта)
+// или асимметричная (подпись RSA SHA-256 ключом, выданным банком).
+//
+//	cb := callback.Callback{OrderID: orderID, OrderNumber: "10747", Operation: callback.Deposited, Success: true}
+//	values, err := cb.Sign(callback.HMAC("secret"))
+//	resp, err := callback.Send(ctx, http.DefaultClient, http.MethodPost, "http://localhost:8000/bereke/callback", values)
+package callback
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/bsagat/bereke-merchant-api/internal/sign"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+// Operation — тип операции в колбэке (параметр operation).
+type Operation = types.CallbackOperation
+
+const (
+	Approved          = types.CallbackApproved          // средства заблокированы (предавторизация)
+	Deposited         = types.CallbackDeposited         // средства списаны
+	Reversed          = types.CallbackReversed          // авторизация отменена
+	Refunded          = types.CallbackRefunded          // выполнен возврат
+	DeclinedByTimeout = types.CallbackDeclinedByTimeout // заказ отклонён по истечении срока оплаты
+)
+
+// Operations — все типы операций колбэка.
+var Operations = []Operation{Approved, Deposited, Reversed, Refunded, DeclinedByTimeout}
+
+// Callback — уведомление шлюза об операции над заказом.
+type Callback struct {
+	OrderID     string     // ID заказа в шлюзе (mdOrder)
+	OrderNumber string     // Номер заказа в системе мерчанта
+	Operation   Operation  // Тип операции
+	Success     bool       // Результат операции: status=1 — успех, status=0 — ошибка
+	Amount      int        // Сумма операции в минорных единицах (0 — параметр не передаётся)
+	Params      url.Values // Дополнительные параметры (например, bindingId, clientId)
+}
+
+// Values — параметры колбэка без контрольной суммы.
+func (c Callback) Values() url.Values {
+	values := url.Values{}
+	for name, v := range c.Params {
+		values[name] = append([]string(nil), v...)
+	}
+
+	values.Set("mdOrder", c.OrderID)
+	values.Set("orderNumber", c.OrderNumber)
+	values.Set("operation", string(c.Operation))
+	if c.Success {
+		values.Set("status", "1")
+	} else {
+		values.Set("status", "0")
+	}
+	if c.Amount != 0 {
+		values.Set("amount", strconv.Itoa(c.Amount))
+	}
+	return values
+}
+
+// Sign — параметры колбэка с контрольной суммой checksum (и sign_alias для RSA).
+func (c Callback) Sign(signer Signer) (url.Values, error) {
+	if !c.Operation.Valid() {
+		return nil, fmt.Errorf("unknown callback operation %q", c.Operation)
+	}
+
+	values := c.Values()
+	if alias := signer.Alias(); alias != "" {
+		values.Set(sign.SignAliasParam, alias)
+	}
+
+	checksum, err := signer.Checksum(sign.CallbackString(values))
+	if err != nil {
+		return nil, err
+	}
+	values.Set(sign.ChecksumParam, checksum)
+	return values, nil
+}
+
+// Signer — способ расчёта контрольной суммы колбэка.
+type Signer interface {
+	// Checksum — контрольная сумма строки колбэка.
+	Checksum(data string) (string, error)
+	// Alias — значение sign_alias (пусто — параметр не передаётся).
+	Alias() string
+}
+
+type hmacSigner struct {
+	key string
+}
+
+// HMAC — симметричная контрольная сумма: HMAC-SHA256 с секретом мерчанта, hex в верхнем регистре.
+func HMAC(key string) Signer {
+	return hmacSigner{key: key}
+}
+
+func (s hmacSigner) Checksum(data string) (string, error) {
+	return sign.HMACSHA256(s.key, data), nil
+}
+
+func (s hmacSigner) Alias() string {
+	return ""
+}
+
+type rsaSigner struct {
+	key   *rsa.PrivateKey
+	alias string
+}
+
+// RSA — асимметричная контрольная сумма: подпись RSA SHA-256, hex в верхнем регистре.
+// alias передаётся в параметре sign_alias (имя сертификата, которым мерчант проверяет подпись).
+func RSA(key *rsa.PrivateKey, alias string) Signer {
+	return rsaSigner{key: key, alias: alias}
+}
+
+// RSAFromFile — асимметричная подпись ключом из PEM-файла, зашифрованного паролем.
+func RSAFromFile(path, password, alias string) (Signer, error) {
+	key, err := sign.LoadEncryptedPrivateKey(path, []byte(password))
+	if err != nil {
+		return nil, err
+	}
+	return RSA(key, alias), nil
+}
+
+func (s rsaSigner) Checksum(data string) (string, error) {
+	signature, err := sign.SignDigest(s.key, sign.SHA256(data))
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(signature)), nil
+}
+
+func (s rsaSigner) Alias() string {
+	return s.alias
+}
+
+// Send — отправка колбэка на target. GET передаёт параметры в URL (так колбэки отправляет шлюз),
+// POST — в теле запроса (application/x-www-form-urlencoded).
+func Send(ctx context.Context, client *http.Client, method, target string, values url.Values) (*http.Response, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+
+	var req *http.Request
+	switch method {
+	case http.MethodGet:
+		query := u.Query()
+		for name, v := range values {
+			query[name] = v
+		}
+		u.RawQuery = query.Encode()
+		req, err = http.NewRequestWithContext(ctx, method, u.String(), nil)
+	case http.MethodPost:
+		req, err = http.NewRequestWithContext(ctx, method, u.String(), strings.NewReader(values.Encode()))
+		if req != nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported callback method %q", method)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Do(req)
+}
@@ -0,0 +1,126 @@
+package callback_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+	"github.com/bsagat/bereke-merchant-api/bereketest/callback"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+	"github.com/bsagat/bereke-merchant-api/webhook"
+)
+
+// completedAPI — шлюз, подтверждающий списание по любому заказу.
+type completedAPI struct {
+	bereke.API
+}
+
+func (completedAPI) GetOrderStatus(context.Context, core.OrderStatusRequest) (core.OrderStatusResponse, error) {
+	return core.OrderStatusResponse{
+		OrderStatus:       types.OrderStatusCompleted,
+		PaymentAmountInfo: core.PaymentAmountInfo{DepositedAmount: 1000},
+	}, nil
+}
+
+func TestSignHMAC(t *testing.T) {
+	cb := callback.Callback{
+		OrderID:     "3ff6962a-7dcc-4283-ab50-a6d7dd3386fe",
+		OrderNumber: "10747",
+		Operation:   callback.Deposited,
+		Success:     true,
+		Amount:      1000,
+	}
+	values, err := cb.Sign(callback.HMAC("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// HMAC-SHA256("secret", "amount;1000;mdOrder;3ff6962a-...;operation;deposited;orderNumber;10747;status;1;")
+	const want = "70975C0E126D1A04EF7399B76C4C18F23BF253E3DF784EEFB05CB3A7A96BF0B2"
+	if got := values.Get("checksum"); got != want {
+		t.Errorf("checksum = %s, want %s", got, want)
+	}
+	if values.Has("sign_alias") {
+		t.Error("sign_alias is set for a symmetric checksum")
+	}
+}
+
+func TestSignUnknownOperation(t *testing.T) {
+	cb := callback.Callback{OrderID: "order-1", Operation: "paid"}
+	if _, err := cb.Sign(callback.HMAC("secret")); err == nil {
+		t.Error("callback with unknown operation was signed")
+	}
+}
+
+func TestWebhookRoundTrip(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		cfg    webhook.Config
+		signer callback.Signer
+		method string
+		tamper bool
+		want   int
+	}{
+		{name: "hmac get", cfg: webhook.Config{HMACKey: "secret"}, signer: callback.HMAC("secret"), method: http.MethodGet, want: http.StatusOK},
+		{name: "hmac post", cfg: webhook.Config{HMACKey: "secret"}, signer: callback.HMAC("secret"), method: http.MethodPost, want: http.StatusOK},
+		{name: "rsa get", cfg: webhook.Config{PublicKey: &key.PublicKey}, signer: callback.RSA(key, "bank"), method: http.MethodGet, want: http.StatusOK},
+		{name: "hmac tampered", cfg: webhook.Config{HMACKey: "secret"}, signer: callback.HMAC("secret"), method: http.MethodGet, tamper: true, want: http.StatusForbidden},
+		{name: "rsa tampered", cfg: webhook.Config{PublicKey: &key.PublicKey}, signer: callback.RSA(key, "bank"), method: http.MethodGet, tamper: true, want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var delivered []webhook.Event
+			handler := webhook.New(completedAPI{}, func(_ context.Context, event webhook.Event) error {
+				delivered = append(delivered, event)
+				return nil
+			}, tt.cfg)
+			merchant := httptest.NewServer(handler)
+			defer merchant.Close()
+
+			cb := callback.Callback{OrderID: "order-1", OrderNumber: "A-1", Operation: callback.Deposited, Success: true, Amount: 1000}
+			values, err := cb.Sign(tt.signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.tamper {
+				values.Set("orderNumber", "A-2")
+			}
+
+			resp, err := callback.Send(context.Background(), merchant.Client(), tt.method, merchant.URL+"/callback", values)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+
+			if tt.want == http.StatusOK {
+				if len(delivered) != 1 || delivered[0].OrderID != "order-1" || delivered[0].Amount != 1000 {
+					t.Errorf("delivered events = %+v, want one deposited callback for order-1", delivered)
+				}
+			} else if len(delivered) != 0 {
+				t.Error("handler called for a tampered callback")
+			}
+		})
+	}
+}
+
+func TestSendUnsupportedMethod(t *testing.T) {
+	_, err := callback.Send(context.Background(), nil, http.MethodPut, "http://localhost/callback", nil)
+	if err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("err = %v, want unsupported method", err)
+	}
+}
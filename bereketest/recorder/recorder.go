@@ -0,0 +1,384 @@
+// Package recorder — запись и воспроизведение HTTP-трафика к платёжному шлюзу для интеграционных тестов.
+//
+// В режиме ModeRecord запросы уходят в шлюз (обычно TEST-среду), а пары запрос/ответ
+// сохраняются в файл-кассету. В режиме ModeReplay ответы берутся из кассеты без обращения к сети,
+// поэтому тесты в CI проверяют декодирование реальных ответов шлюза детерминированно.
+//
+// Учётные данные, карточные данные и платёжные токены в кассету не попадают:
+// значения чувствительных параметров заменяются на "[SCRUBBED]" как в запросах, так и в ответах.
+//
+//	rec, err := recorder.New("testdata/register.json", recorder.ModeReplay, recorder.Config{})
+//	defer rec.Stop()
+//
+//	api, err := bereke_merchant.NewWithLogin(login, password, types.TEST,
+//		bereke_merchant.WithHTTPClient(&http.Client{Transport: rec}))
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mode — режим работы транспорта.
+type Mode int
+
+const (
+	ModeReplay Mode = iota // Ответы только из кассеты; запрос без записи — ошибка
+	ModeRecord             // Запросы уходят в сеть, кассета перезаписывается при Stop
+)
+
+// Scrubbed — значение, которым заменяются чувствительные данные.
+const Scrubbed = "[SCRUBBED]"
+
+// DefaultScrubKeys — параметры запроса и поля JSON, значения которых не сохраняются в кассету.
+var DefaultScrubKeys = []string{
+	"userName", "password", "token", "merchant",
+	"pan", "$PAN", "cvc", "$CVC", "CVC", "expiry", "$EXPIRY", "MM", "YYYY", "cardholderName", "TEXT",
+	"paymentToken", "seToken", "bindingId",
+	"PaReq", "paReq", "PaRes", "paRes", "creq", "cres",
+}
+
+// ErrNoInteraction — в кассете нет записи для запроса (ModeReplay).
+var ErrNoInteraction = errors.New("recorder: no recorded interaction for request")
+
+// Config — параметры транспорта.
+type Config struct {
+	// Транспорт для реальных запросов в ModeRecord (по умолчанию http.DefaultTransport)
+	Transport http.RoundTripper
+
+	// Дополнительные параметры/поля, значения которых нужно скрыть (к DefaultScrubKeys)
+	ScrubKeys []string
+
+	// Параметры и поля JSON-тела (на любом уровне вложенности), не участвующие в сопоставлении
+	// при воспроизведении (например, orderNumber, который генерируется заново при каждом запуске теста)
+	IgnoreParams []string
+}
+
+// Recorder — http.RoundTripper с записью и воспроизведением кассет.
+type Recorder struct {
+	path   string
+	mode   Mode
+	cfg    Config
+	scrub  map[string]bool
+	ignore map[string]bool
+
+	mu       sync.Mutex
+	cassette cassette
+	served   map[string]int // количество выданных ответов по ключу (ModeReplay)
+}
+
+var _ http.RoundTripper = (*Recorder)(nil)
+
+// New — создание транспорта. В ModeReplay кассета загружается из path и должна существовать.
+func New(path string, mode Mode, cfg Config) (*Recorder, error) {
+	if cfg.Transport == nil {
+		cfg.Transport = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		path:   path,
+		mode:   mode,
+		cfg:    cfg,
+		scrub:  map[string]bool{},
+		ignore: map[string]bool{},
+		served: map[string]int{},
+	}
+	for _, key := range cfg.IgnoreParams {
+		r.ignore[key] = true
+	}
+	for _, key := range append(append([]string{}, DefaultScrubKeys...), cfg.ScrubKeys...) {
+		r.scrub[key] = true
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("recorder: invalid cassette %s: %w", path, err)
+		}
+	}
+	return r, nil
+}
+
+// RoundTrip — выполнение запроса (ModeRecord) или выдача записанного ответа (ModeReplay).
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recorded := r.recordRequest(req, body)
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := r.cfg.Transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction{
+		Request: recorded,
+		Response: response{
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        r.scrubBody(respBody),
+		},
+	})
+	r.mu.Unlock()
+
+	// Вызывающей стороне отдаётся исходный ответ без скрытия данных
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+// Stop — завершение работы; в ModeRecord кассета сохраняется в файл.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// replay — выдача записанного ответа. Записи с одинаковым ключом выдаются по порядку,
+// после исчерпания повторяется последняя.
+func (r *Recorder) replay(req *http.Request, recorded request) (*http.Response, error) {
+	key := r.key(recorded)
+
+	r.mu.Lock()
+	var matches []response
+	for _, it := range r.cassette.Interactions {
+		if r.key(it.Request) == key {
+			matches = append(matches, it.Response)
+		}
+	}
+	if len(matches) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrNoInteraction, key)
+	}
+	n := r.served[key]
+	r.served[key]++
+	r.mu.Unlock()
+
+	if n >= len(matches) {
+		n = len(matches) - 1
+	}
+	resp := matches[n]
+
+	header := http.Header{}
+	if resp.ContentType != "" {
+		header.Set("Content-Type", resp.ContentType)
+	}
+	return &http.Response{
+		StatusCode:    resp.StatusCode,
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}, nil
+}
+
+// recordRequest — запрос в виде, сохраняемом в кассету: путь и нормализованные параметры
+// из query string, form-тела или JSON-тела со скрытыми чувствительными значениями.
+func (r *Recorder) recordRequest(req *http.Request, body []byte) request {
+	params := url.Values{}
+	for k, vs := range req.URL.Query() {
+		params[k] = vs
+	}
+
+	var jsonBody string
+	contentType := req.Header.Get("Content-Type")
+	switch {
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded") && len(body) > 0:
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for k, vs := range form {
+				params[k] = append(params[k], vs...)
+			}
+		}
+	case strings.HasPrefix(contentType, "application/json") && len(body) > 0:
+		jsonBody = r.scrubBody(body)
+	}
+
+	for k := range params {
+		if r.scrub[k] {
+			params[k] = []string{Scrubbed}
+		}
+	}
+
+	return request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Params: params,
+		Body:   jsonBody,
+	}
+}
+
+// scrubBody — скрытие чувствительных полей JSON-документа (на любом уровне вложенности)
+// и нормализация: ключи объектов сортируются. Не-JSON тело сохраняется как есть.
+func (r *Recorder) scrubBody(body []byte) string {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return string(body)
+	}
+	data, err := json.Marshal(r.scrubValue(doc))
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+func (r *Recorder) scrubValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			if r.scrub[k] {
+				v[k] = Scrubbed
+			} else {
+				v[k] = r.scrubValue(item)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = r.scrubValue(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// readBody — чтение тела запроса с восстановлением его для дальнейшего использования.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// cassette — содержимое файла-кассеты.
+type cassette struct {
+	Interactions []interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  request  `json:"request"`
+	Response response `json:"response"`
+}
+
+type request struct {
+	Method string     `json:"method"`
+	Path   string     `json:"path"`
+	Params url.Values `json:"params,omitempty"`
+	Body   string     `json:"body,omitempty"`
+}
+
+// key — ключ сопоставления: метод, путь, отсортированные параметры и JSON-тело (без IgnoreParams).
+func (r *Recorder) key(q request) string {
+	keys := make([]string, 0, len(q.Params))
+	for k := range q.Params {
+		if !r.ignore[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(q.Method + " " + q.Path)
+	for i, k := range keys {
+		if i == 0 {
+			b.WriteByte('?')
+		} else {
+			b.WriteByte('&')
+		}
+		values := append([]string{}, q.Params[k]...)
+		sort.Strings(values)
+		b.WriteString(k + "=" + strings.Join(values, ","))
+	}
+	if q.Body != "" {
+		b.WriteString(" " + r.matchBody(q.Body))
+	}
+	return b.String()
+}
+
+// matchBody — JSON-тело запроса без полей из IgnoreParams для сопоставления.
+// Ключи объектов после повторной сериализации отсортированы, поэтому порядок полей не важен.
+func (r *Recorder) matchBody(body string) string {
+	if len(r.ignore) == 0 {
+		return body
+	}
+	var doc interface{}
+	if err := json.Unmarshal([]byte(body), &doc); err != nil {
+		return body
+	}
+	data, err := json.Marshal(r.dropIgnored(doc))
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
+
+func (r *Recorder) dropIgnored(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			if r.ignore[k] {
+				delete(v, k)
+			} else {
+				v[k] = r.dropIgnored(item)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = r.dropIgnored(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+type response struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+}
@@ -0,0 +1,183 @@
+package recorder_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+	"github.com/bsagat/bereke-merchant-api/bereketest/recorder"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+const (
+	cassetteOrderID = "0199f4a2-6b1c-7d3e-9f10-2a3b4c5d6e7f"
+	googlePayToken  = "eyJzaWduYXR1cmUiOiJNRVVD"
+)
+
+func newClient(t *testing.T, rec *recorder.Recorder) bereke.API {
+	t.Helper()
+	api, err := bereke.NewWithLogin("merchant-api", "secret", types.TEST,
+		bereke.WithHTTPClient(&http.Client{Transport: rec}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return api
+}
+
+func TestReplayCassette(t *testing.T) {
+	rec, err := recorder.New("testdata/gateway.json", recorder.ModeReplay, recorder.Config{
+		IgnoreParams: []string{"orderNumber"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Stop()
+
+	api := newClient(t, rec)
+	ctx := context.Background()
+
+	// Номер заказа отличается от записанного — он исключён из сопоставления
+	order, err := api.RegisterOrder(ctx, core.RegisterOrderRequest{Order: core.Order{
+		OrderNumber: "run-42",
+		Amount:      1500,
+		Currency:    398,
+		ReturnURL:   "https://shop.example.com/return",
+	}})
+	if err != nil {
+		t.Fatalf("RegisterOrder: %v", err)
+	}
+	if order.OrderID != cassetteOrderID || !strings.Contains(order.FormURL, "mdOrder="+cassetteOrderID) {
+		t.Errorf("RegisterOrder = %+v", order)
+	}
+
+	status, err := api.GetOrderStatusByID(ctx, order.OrderID)
+	if err != nil {
+		t.Fatalf("GetOrderStatusByID: %v", err)
+	}
+	want := core.OrderStatusResponse{
+		Response:    core.Response{ErrorMessage: "Успешно"},
+		Amount:      1500,
+		Currency:    398,
+		OrderNumber: "cassette-0001",
+		OrderStatus: types.OrderStatusCompleted,
+		AuthRefNum:  "529012345678",
+		TerminalID:  "90000001",
+
+		Date:          1760860800123,
+		DepositedDate: 1760860865000,
+
+		PaymentAmountInfo: core.PaymentAmountInfo{ApprovedAmount: 150000, DepositedAmount: 150000, PaymentState: "DEPOSITED"},
+		BankInfo:          core.BankInfo{BankName: "BEREKE BANK", BankCountry: "KZ", BankCountryName: "Казахстан"},
+		CardInfo:          core.CardInfo{MaskedPan: "440043**0000", Expiration: "202812", CardholderName: recorder.Scrubbed, ApprovalCode: "123456"},
+		PaymentWay:        "CARD",
+	}
+	if status != want {
+		t.Errorf("GetOrderStatusByID:\n got  %+v\n want %+v", status, want)
+	}
+
+	// orderNumber в JSON-теле тоже исключён из сопоставления, токен и логин скрыты в кассете
+	payment, err := api.PayWithGooglePay(ctx, core.GooglePayRequest{
+		OrderNumber:  "run-43",
+		PaymentToken: googlePayToken,
+		Amount:       500,
+		Currency:     398,
+		AuthMethod:   types.GooglePayCryptogram3DS,
+	})
+	if err != nil {
+		t.Fatalf("PayWithGooglePay: %v", err)
+	}
+	if payment.ErrorCode != 0 || payment.OrderID != "0199f4a3-1c2d-7e4f-8a9b-0c1d2e3f4a5b" || payment.ThreeDS != nil {
+		t.Errorf("PayWithGooglePay = %+v", payment)
+	}
+
+	// Другая сумма — другой запрос
+	_, err = api.PayWithGooglePay(ctx, core.GooglePayRequest{
+		OrderNumber:  "run-44",
+		PaymentToken: googlePayToken,
+		Amount:       700,
+		Currency:     398,
+		AuthMethod:   types.GooglePayCryptogram3DS,
+	})
+	if !errors.Is(err, recorder.ErrNoInteraction) {
+		t.Errorf("unmatched request: err = %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestReplayMatchesOrderNumberWithoutIgnore(t *testing.T) {
+	rec, err := recorder.New("testdata/gateway.json", recorder.ModeReplay, recorder.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := newClient(t, rec)
+
+	_, err = api.PayWithGooglePay(context.Background(), core.GooglePayRequest{
+		OrderNumber:  "run-43",
+		PaymentToken: googlePayToken,
+		Amount:       500,
+		Currency:     398,
+		AuthMethod:   types.GooglePayCryptogram3DS,
+	})
+	if !errors.Is(err, recorder.ErrNoInteraction) {
+		t.Errorf("err = %v, want ErrNoInteraction", err)
+	}
+}
+
+// gateway — транспорт с фиксированными ответами вместо сети.
+type gateway map[string]string
+
+func (g gateway) RoundTrip(req *http.Request) (*http.Response, error) {
+	for suffix, body := range g {
+		if strings.HasSuffix(req.URL.Path, suffix) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}
+	}
+	return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody}, nil
+}
+
+func TestRecordScrubsSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec, err := recorder.New(path, recorder.ModeRecord, recorder.Config{
+		Transport: gateway{"/google/payment.do": `{"success":true,"data":{"orderId":"id-1"}}`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api := newClient(t, rec)
+	payment, err := api.PayWithGooglePay(context.Background(), core.GooglePayRequest{
+		OrderNumber:  "rec-1",
+		PaymentToken: googlePayToken,
+		Amount:       10,
+		Currency:     398,
+	})
+	if err != nil {
+		t.Fatalf("PayWithGooglePay: %v", err)
+	}
+	if payment.OrderID != "id-1" {
+		t.Errorf("OrderID = %q, want id-1", payment.OrderID)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"merchant-api", "secret", googlePayToken} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package bereke_merchant
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/models/code"
+)
+
+// ErrCircuitOpen — запрос не отправлен: circuit breaker разомкнут, шлюз считается недоступным.
+// Получив эту ошибку, можно сразу предложить клиенту другой способ оплаты.
+var ErrCircuitOpen = errors.New("circuit breaker is open: payment gateway is unavailable")
+
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // Запросы проходят в обычном режиме
+	CircuitOpen                         // Запросы отклоняются без обращения к шлюзу
+	CircuitHalfOpen                     // Идёт пробный запрос для проверки восстановления шлюза
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultFailureCodes — коды ошибки шлюза (errorCode), которые считаются признаком его деградации.
+var DefaultFailureCodes = []code.ErrorCode{
+	code.ErrorSystem,
+}
+
+// DefaultFailureActionCodes — коды ответа процессинга (actionCode), которые считаются признаком его деградации.
+// Учитываются только в ответах endpoint'ов оплаты (liveActionCodeEndpoints): в ответе запроса статуса
+// actionCode исторический и может относиться к попытке оплаты, сделанной задолго до запроса.
+var DefaultFailureActionCodes = []code.ActionCode{
+	code.IssuerUnavailable,
+	code.BankUnavailable,
+	code.SystemMalfunction,
+	code.ServiceUnavailable,
+	code.ProcessingTimeoutSendFailed,
+	code.ProcessingTimeoutNoResponse,
+}
+
+// CircuitBreakerConfig — параметры circuit breaker.
+type CircuitBreakerConfig struct {
+	// Количество сбоев подряд, после которого цепь размыкается (по умолчанию 5)
+	FailureThreshold int
+
+	// Время в разомкнутом состоянии до пробного запроса (по умолчанию 30 секунд)
+	OpenTimeout time.Duration
+
+	// Коды ошибки шлюза, считающиеся сбоем (по умолчанию DefaultFailureCodes).
+	// Сетевые ошибки, таймауты и HTTP 5xx считаются сбоем всегда
+	FailureCodes []code.ErrorCode
+
+	// Коды ответа процессинга, считающиеся сбоем (по умолчанию DefaultFailureActionCodes).
+	// Применяются только к endpoint'ам оплаты, которые возвращают результат текущей операции
+	FailureActionCodes []code.ActionCode
+
+	// Проба доступности шлюза в состоянии half-open (необязательно).
+	// Если не задана, проба выполняется через Ping клиента, запрос которого пришёл
+	// в half-open, — так breaker можно разделять между несколькими клиентами.
+	// ctx — контекст этого запроса: его отмена или дедлайн прерывают пробу
+	Probe func(ctx context.Context) error
+
+	// Вызывается при каждой смене состояния (необязательно)
+	OnStateChange func(from, to CircuitState)
+}
+
+// CircuitBreaker — защита от каскадных таймаутов при деградации шлюза.
+// После FailureThreshold сбоев подряд запросы отклоняются с ErrCircuitOpen в течение OpenTimeout,
+// затем выполняется проба (half-open): при успехе цепь замыкается, иначе снова размыкается.
+//
+// Подключается к клиенту опцией WithCircuitBreaker; состояние доступно через State.
+// Один breaker можно подключить к нескольким клиентам одного шлюза.
+type CircuitBreaker struct {
+	mu       sync.Mutex
+	cfg      CircuitBreakerConfig
+	codes    map[code.ErrorCode]bool
+	actions  map[code.ActionCode]bool
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+	// true — пробой служит сам запрос, его результат учтёт record
+	requestProbe bool
+	changes      [][2]CircuitState // смены состояния, ожидающие вызова OnStateChange
+}
+
+// NewCircuitBreaker — создание circuit breaker с параметрами по умолчанию для незаданных полей.
+func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.FailureCodes == nil {
+		cfg.FailureCodes = DefaultFailureCodes
+	}
+	if cfg.FailureActionCodes == nil {
+		cfg.FailureActionCodes = DefaultFailureActionCodes
+	}
+
+	codes := make(map[code.ErrorCode]bool, len(cfg.FailureCodes))
+	for _, c := range cfg.FailureCodes {
+		codes[c] = true
+	}
+	actions := make(map[code.ActionCode]bool, len(cfg.FailureActionCodes))
+	for _, c := range cfg.FailureActionCodes {
+		actions[c] = true
+	}
+	return &CircuitBreaker{cfg: cfg, codes: codes, actions: actions}
+}
+
+// WithCircuitBreaker — подключение circuit breaker к клиенту.
+// Если CircuitBreakerConfig.Probe не задана, пробы в состоянии half-open
+// выполняются через Ping того клиента, чей запрос пришёл в half-open.
+func WithCircuitBreaker(b *CircuitBreaker) Option {
+	return func(a *api) {
+		a.breaker = b
+	}
+}
+
+// State — текущее состояние цепи.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// allow — проверка, можно ли отправить запрос. Безопасен для nil (breaker не подключён).
+// probe — проба клиента, используется, если CircuitBreakerConfig.Probe не задана;
+// если обе nil, пробой служит сам запрос.
+func (b *CircuitBreaker) allow(ctx context.Context, probe func(context.Context) error) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	switch {
+	case b.state == CircuitClosed:
+		b.unlock()
+		return nil
+	case b.probing || time.Since(b.openedAt) < b.cfg.OpenTimeout:
+		b.unlock()
+		return ErrCircuitOpen
+	}
+
+	if b.cfg.Probe != nil {
+		probe = b.cfg.Probe
+	}
+	b.probing = true
+	b.requestProbe = probe == nil
+	b.setState(CircuitHalfOpen)
+	b.unlock()
+
+	// Без пробы текущий запрос становится пробным, его результат учтёт record
+	if probe == nil {
+		return nil
+	}
+
+	err := probe(ctx)
+
+	b.mu.Lock()
+	defer b.unlock()
+	b.probing = false
+	// Отмена запроса вызывающей стороной не говорит о состоянии шлюза: цепь остаётся
+	// разомкнутой без сдвига OpenTimeout, следующий запрос повторит пробу
+	if errors.Is(err, context.Canceled) && ctx.Err() != nil {
+		b.setState(CircuitOpen)
+		return err
+	}
+	if err != nil {
+		b.trip()
+		return ErrCircuitOpen
+	}
+	b.failures = 0
+	b.setState(CircuitClosed)
+	return nil
+}
+
+// record — учёт результата запроса. Безопасен для nil.
+func (b *CircuitBreaker) record(err error, httpStatus int, errorCode code.ErrorCode, actionCode code.ActionCode) {
+	if b == nil {
+		return
+	}
+	failed := err != nil || httpStatus >= 500 || b.codes[errorCode] || b.actions[actionCode]
+
+	b.mu.Lock()
+	defer b.unlock()
+
+	// Отмена запроса вызывающей стороной не говорит о состоянии шлюза
+	canceled := errors.Is(err, context.Canceled)
+
+	if b.state == CircuitHalfOpen && b.requestProbe {
+		b.probing = false
+		b.requestProbe = false
+		if canceled {
+			// Проба не завершилась — следующий запрос повторит её
+			b.setState(CircuitOpen)
+			return
+		}
+		if failed {
+			b.trip()
+		} else {
+			b.failures = 0
+			b.setState(CircuitClosed)
+		}
+		return
+	}
+	if canceled {
+		return
+	}
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == CircuitClosed && b.failures >= b.cfg.FailureThreshold {
+		b.trip()
+	}
+}
+
+// trip — размыкание цепи. Вызывается под mu.
+func (b *CircuitBreaker) trip() {
+	b.openedAt = time.Now()
+	b.setState(CircuitOpen)
+}
+
+// setState — смена состояния. Вызывается под mu; OnStateChange вызывается в unlock.
+func (b *CircuitBreaker) setState(state CircuitState) {
+	if b.state == state {
+		return
+	}
+	b.changes = append(b.changes, [2]CircuitState{b.state, state})
+	b.state = state
+}
+
+// unlock — освобождает mu и уведомляет о сменах состояния уже без блокировки,
+// чтобы OnStateChange мог безопасно вызывать State.
+func (b *CircuitBreaker) unlock() {
+	changes := b.changes
+	b.changes = nil
+	b.mu.Unlock()
+
+	if b.cfg.OnStateChange == nil {
+		return
+	}
+	for _, c := range changes {
+		b.cfg.OnStateChange(c[0], c[1])
+	}
+}
+
+// liveActionCodeEndpoints — endpoint'ы оплаты, в ответе которых actionCode —
+// результат только что выполненной операции в процессинге.
+var liveActionCodeEndpoints = map[string]bool{
+	"paymentorder.do":     true,
+	"finishThreeDs.do":    true,
+	"applepay/payment.do": true,
+	"google/payment.do":   true,
+}
+
+// breakerActionCode — actionCode ответа для учёта в circuit breaker:
+// для остальных endpoint'ов (например, getOrderStatusExtended.do) возвращается 0.
+func breakerActionCode(path string, actionCode code.ActionCode) code.ActionCode {
+	if liveActionCodeEndpoints[path] {
+		return actionCode
+	}
+	return 0
+}
+
+// errorCoder — ответ шлюза с кодом ошибки (реализуется dto-структурами ответов).
+type errorCoder interface {
+	GatewayErrorCode() code.ErrorCode
+}
+
+func gatewayErrorCode(result interface{}) code.ErrorCode {
+	if c, ok := result.(errorCoder); ok {
+		return c.GatewayErrorCode()
+	}
+	return 0
+}
@@ -0,0 +1,259 @@
+package bereke_merchant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/models/code"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+func TestCircuitBreakerIgnoresHistoricalActionCode(t *testing.T) {
+	// Заказ был отклонён когда-то из-за недоступности эмитента — сам запрос статуса успешен
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"errorCode":"0","orderStatus":6,"actionCode":907,"orderNumber":"A-1"}`))
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+	client, err := NewWithToken("token", types.TEST, WithGatewayURL(server.URL+"/payment/"), WithCircuitBreaker(breaker))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		status, err := client.GetOrderStatusByID(context.Background(), "order-1")
+		if err != nil {
+			t.Fatalf("request %d: %v", i+1, err)
+		}
+		if status.ActionCode != code.IssuerUnavailable {
+			t.Fatalf("actionCode = %d, want %d", status.ActionCode, code.IssuerUnavailable)
+		}
+	}
+	if state := breaker.State(); state != CircuitClosed {
+		t.Errorf("breaker state = %s, want %s", state, CircuitClosed)
+	}
+}
+
+func TestBreakerActionCode(t *testing.T) {
+	tests := []struct {
+		path string
+		want code.ActionCode
+	}{
+		{"paymentorder.do", code.IssuerUnavailable},
+		{"finishThreeDs.do", code.IssuerUnavailable},
+		{"applepay/payment.do", code.IssuerUnavailable},
+		{"google/payment.do", code.IssuerUnavailable},
+		{"getOrderStatusExtended.do", 0},
+		{"register.do", 0},
+	}
+	for _, tt := range tests {
+		if got := breakerActionCode(tt.path, code.IssuerUnavailable); got != tt.want {
+			t.Errorf("%s: actionCode = %d, want %d", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCircuitBreakerProbesRequestingClient(t *testing.T) {
+	var pingsA, pingsB atomic.Int32
+	newServer := func(pings *atomic.Int32, status int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/rest") {
+				pings.Add(1)
+				return
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(`{"errorCode":"0","orderStatus":2}`))
+		}))
+	}
+	serverA := newServer(&pingsA, http.StatusBadGateway)
+	defer serverA.Close()
+	serverB := newServer(&pingsB, http.StatusOK)
+	defer serverB.Close()
+
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond})
+	clientA, err := NewWithToken("token", types.TEST, WithGatewayURL(serverA.URL), WithCircuitBreaker(breaker))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientB, err := NewWithToken("token", types.TEST, WithGatewayURL(serverB.URL), WithCircuitBreaker(breaker))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, err := clientA.GetOrderStatusByID(ctx, "order-1"); err == nil {
+		t.Fatal("expected HTTP error from client A")
+	}
+	if state := breaker.State(); state != CircuitOpen {
+		t.Fatalf("breaker state = %s, want %s", state, CircuitOpen)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if _, err := clientB.GetOrderStatusByID(ctx, "order-1"); err != nil {
+		t.Fatalf("client B: %v", err)
+	}
+	if pingsA.Load() != 0 || pingsB.Load() != 1 {
+		t.Errorf("pings: A = %d, B = %d; want probe through client B only", pingsA.Load(), pingsB.Load())
+	}
+	if state := breaker.State(); state != CircuitClosed {
+		t.Errorf("breaker state = %s, want %s", state, CircuitClosed)
+	}
+}
+
+func TestCircuitBreakerProbeContext(t *testing.T) {
+	var probes atomic.Int32
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{
+		FailureThreshold: 1,
+		OpenTimeout:      time.Millisecond,
+		Probe: func(ctx context.Context) error {
+			probes.Add(1)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+	breaker.record(errors.New("timeout"), 0, 0, 0)
+	time.Sleep(5 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := breaker.allow(ctx, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("allow: err = %v, want context.Canceled", err)
+	}
+	if probes.Load() != 1 {
+		t.Fatalf("probes = %d, want 1", probes.Load())
+	}
+	if state := breaker.State(); state != CircuitOpen {
+		t.Fatalf("breaker state = %s, want %s", state, CircuitOpen)
+	}
+
+	// Отменённая проба не сдвигает OpenTimeout: следующий запрос сразу пробует снова
+	breaker.cfg.Probe = func(context.Context) error { probes.Add(1); return nil }
+	if err := breaker.allow(context.Background(), nil); err != nil {
+		t.Fatalf("allow after canceled probe: %v", err)
+	}
+	if probes.Load() != 2 || breaker.State() != CircuitClosed {
+		t.Errorf("probes = %d, state = %s; want 2, %s", probes.Load(), breaker.State(), CircuitClosed)
+	}
+}
+
+func TestCircuitBreakerOpensOnPaymentActionCode(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/paymentorder.do") {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"errorCode":"0","actionCode":912,"info":"Эмитент недоступен"}`))
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 3, OpenTimeout: time.Hour})
+	client, err := NewWithToken("token", types.TEST, WithGatewayURL(server.URL), WithCircuitBreaker(breaker))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := core.CardPaymentRequest{OrderID: "order-1", PAN: "4111111111111111", CVC: "123", Expiry: "209912"}
+	for i := 0; i < 3; i++ {
+		res, err := client.PayOrder(context.Background(), req)
+		if err != nil {
+			t.Fatalf("payment %d: %v", i+1, err)
+		}
+		if res.ActionCode != code.BankUnavailable {
+			t.Fatalf("actionCode = %d, want %d", res.ActionCode, code.BankUnavailable)
+		}
+	}
+	if state := breaker.State(); state != CircuitOpen {
+		t.Fatalf("breaker state = %s, want %s", state, CircuitOpen)
+	}
+
+	if _, err := client.PayOrder(context.Background(), req); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("payment with open breaker: err = %v, want ErrCircuitOpen", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests sent = %d, want 3", got)
+	}
+}
+
+func TestCircuitBreakerLifecycle(t *testing.T) {
+	var (
+		failing         atomic.Bool
+		pings, payments atomic.Int32
+		transitions     []string
+	)
+	failing.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/rest") {
+			pings.Add(1)
+			return
+		}
+		payments.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if failing.Load() {
+			w.Write([]byte(`{"errorCode":"0","actionCode":907}`))
+			return
+		}
+		w.Write([]byte(`{"errorCode":"0","redirect":"https://shop.example.com/ok"}`))
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{
+		FailureThreshold: 2,
+		OpenTimeout:      20 * time.Millisecond,
+		OnStateChange: func(from, to CircuitState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	client, err := NewWithToken("token", types.TEST, WithGatewayURL(server.URL), WithCircuitBreaker(breaker))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	req := core.CardPaymentRequest{OrderID: "order-1", PAN: "4111111111111111", CVC: "123", Expiry: "209912"}
+
+	// Сбои подряд размыкают цепь
+	for i := 0; i < 2; i++ {
+		if _, err := client.PayOrder(ctx, req); err != nil {
+			t.Fatalf("payment %d: %v", i+1, err)
+		}
+	}
+	if _, err := client.PayOrder(ctx, req); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("payment with open breaker: err = %v, want ErrCircuitOpen", err)
+	}
+	if pings.Load() != 0 || payments.Load() != 2 {
+		t.Fatalf("pings = %d, payments = %d; want 0, 2", pings.Load(), payments.Load())
+	}
+
+	// После OpenTimeout проба идёт через Ping клиента, успех замыкает цепь
+	failing.Store(false)
+	time.Sleep(30 * time.Millisecond)
+	res, err := client.PayOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("payment after recovery: %v", err)
+	}
+	if res.Redirect == "" {
+		t.Error("payment after recovery: empty redirect")
+	}
+	if pings.Load() != 1 || payments.Load() != 3 {
+		t.Errorf("pings = %d, payments = %d; want 1, 3", pings.Load(), payments.Load())
+	}
+	if state := breaker.State(); state != CircuitClosed {
+		t.Errorf("breaker state = %s, want %s", state, CircuitClosed)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if strings.Join(transitions, ",") != strings.Join(want, ",") {
+		t.Errorf("transitions = %v, want %v", transitions, want)
+	}
+}
@@ -0,0 +1,81 @@
+package bereke_merchant
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/models/core"
+)
+
+// Ошибки локальной проверки карточных данных.
+// Возвращаются до отправки запроса в шлюз; сами карточные данные в текст ошибки не попадают.
+var (
+	ErrInvalidPAN    = errors.New("invalid card number")
+	ErrInvalidCVC    = errors.New("invalid card CVC")
+	ErrInvalidExpiry = errors.New("invalid card expiry: expected YYYYMM")
+	ErrCardExpired   = errors.New("card is expired")
+)
+
+// validateCardPayment — проверка карточных данных перед отправкой в paymentorder.do.
+func validateCardPayment(req core.CardPaymentRequest, now time.Time) error {
+	if req.OrderID == "" {
+		return errors.New("order ID is required")
+	}
+	if !isDigits(req.PAN) || len(req.PAN) < 12 || len(req.PAN) > 19 || !luhnValid(req.PAN) {
+		return ErrInvalidPAN
+	}
+	if !isDigits(req.CVC) || len(req.CVC) < 3 || len(req.CVC) > 4 {
+		return ErrInvalidCVC
+	}
+	return validateExpiry(req.Expiry, now)
+}
+
+// validateExpiry — проверяет формат срока действия (YYYYMM) и что карта ещё действует.
+// Карта действительна до конца указанного месяца включительно.
+func validateExpiry(expiry string, now time.Time) error {
+	if len(expiry) != 6 || !isDigits(expiry) {
+		return ErrInvalidExpiry
+	}
+
+	year, _ := strconv.Atoi(expiry[:4])
+	month, _ := strconv.Atoi(expiry[4:])
+	if month < 1 || month > 12 {
+		return ErrInvalidExpiry
+	}
+
+	if year < now.Year() || (year == now.Year() && month < int(now.Month())) {
+		return ErrCardExpired
+	}
+	return nil
+}
+
+// luhnValid — проверка номера карты по алгоритму Луна.
+func luhnValid(pan string) bool {
+	sum := 0
+	double := false
+	for i := len(pan) - 1; i >= 0; i-- {
+		digit := int(pan[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
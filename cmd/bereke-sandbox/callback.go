@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/bereketest/callback"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+const (
+	callbackTimeout  = 10 * time.Second // Таймаут одного запроса колбэка
+	callbackAttempts = 3                // Количество попыток доставки колбэка
+	callbackBackoff  = 2 * time.Second  // Пауза между попытками
+)
+
+// notify — асинхронная отправка колбэка на dynamicCallbackUrl заказа так же, как это делает шлюз:
+// GET-запрос с параметрами mdOrder, orderNumber, operation, status (1 — успех, 0 — отказ), amount
+// и контрольной суммой checksum (HMAC-SHA256), если задан --callback-key.
+// Колбэк повторяется, пока мерчант не ответит кодом 2xx или не закончатся попытки.
+func (s *server) notify(o order, operation types.CallbackOperation, success bool) {
+	if o.CallbackURL == "" {
+		return
+	}
+
+	cb := callback.Callback{
+		OrderID:     o.ID,
+		OrderNumber: o.Number,
+		Operation:   operation,
+		Success:     success,
+		Amount:      callbackAmount(o, operation),
+	}
+	values := cb.Values()
+	if s.callbackKey != "" {
+		var err error
+		if values, err = cb.Sign(callback.HMAC(s.callbackKey)); err != nil {
+			log.Printf("callback %s: sign: %v", o.ID, err)
+			return
+		}
+	}
+
+	go s.deliver(o.ID, o.CallbackURL, values)
+}
+
+// callbackAmount — сумма операции в колбэке (минорные единицы).
+func callbackAmount(o order, operation types.CallbackOperation) int {
+	switch operation {
+	case types.CallbackApproved, types.CallbackReversed:
+		return o.ApprovedAmount
+	case types.CallbackDeposited:
+		return o.DepositedAmount
+	case types.CallbackRefunded:
+		return o.LastRefundAmount
+	default:
+		return o.Amount
+	}
+}
+
+func (s *server) deliver(orderID, target string, values url.Values) {
+	for attempt := 1; attempt <= callbackAttempts; attempt++ {
+		resp, err := callback.Send(context.Background(), s.callbacks, http.MethodGet, target, values)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				log.Printf("callback %s: delivered (%s)", orderID, target)
+				return
+			}
+			log.Printf("callback %s: attempt %d: status %d", orderID, attempt, resp.StatusCode)
+		} else {
+			log.Printf("callback %s: attempt %d: %v", orderID, attempt, err)
+		}
+
+		if attempt < callbackAttempts {
+			time.Sleep(callbackBackoff)
+		}
+	}
+	log.Printf("callback %s: not delivered after %d attempts", orderID, callbackAttempts)
+}
@@ -0,0 +1,85 @@
+// Команда bereke-sandbox — локальная песочница платёжного шлюза для разработки
+// и тестов без доступа к 3dsec.berekebank.kz.
+//
+// Песочница эмулирует REST endpoint'ы, которые использует клиент (register.do,
+// registerPreAuth.do, getOrderStatusExtended.do, deposit.do, reverse.do, refund.do,
+// decline.do, paymentorder.do, finishThreeDs.do, verifyEnrollment.do, Apple Pay и Google Pay),
+// и платёжную страницу по адресу formUrl. На странице тестировщик выбирает исход
+// оплаты: успех, отказ с выбранным actionCode, 3-D Secure или истечение срока оплаты.
+// После этого песочница отправляет колбэк на dynamicCallbackUrl и перенаправляет
+// покупателя на returnUrl/failUrl. С --callback-key колбэки подписываются так же,
+// как это делает шлюз (HMAC-SHA256), и проходят проверку webhook.Handler с тем же HMACKey.
+//
+// С --experimental-qr песочница также эмулирует экспериментальные QR-коды клиента
+// (bereke_merchant.DefaultQREndpoints). Эти пути не подтверждены документацией Bereke Bank,
+// поэтому по умолчанию песочница отвечает на них 404, как на неизвестный endpoint.
+//
+// Использование:
+//
+//	bereke-sandbox [--addr :8080] [--public-url http://localhost:8080] [--login L --password P] [--callback-key K] [--experimental-qr]
+//
+// Клиент подключается к песочнице опцией WithGatewayURL:
+//
+//	api, err := bereke_merchant.NewWithLogin("sandbox", "sandbox", types.TEST,
+//		bereke_merchant.WithGatewayURL("http://localhost:8080/payment/"))
+//
+// или для утилиты bereke — переменной окружения BEREKE_URL.
+//
+// Тестовые карты для paymentorder.do: 4000000000000002 — отказ (недостаточно средств),
+// 4000000000003220 — 3-D Secure 2 с challenge (ContinueThreeDS2 → страница ACS → FinishThreeDS),
+// любая другая — успешная оплата.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "ошибка:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("bereke-sandbox", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "адрес HTTP-сервера")
+	publicURL := fs.String("public-url", "", "внешний адрес песочницы для formUrl (по умолчанию http://localhost<addr>)")
+	login := fs.String("login", "", "логин мерчанта (пусто — принимаются любые учётные данные)")
+	password := fs.String("password", "", "пароль мерчанта")
+	callbackKey := fs.String("callback-key", "", "секрет для контрольной суммы колбэков HMAC-SHA256 (пусто — колбэки без checksum)")
+	experimentalQR := fs.Bool("experimental-qr", false, "эмулировать экспериментальные endpoint'ы QR-кодов (sbp/c2b/qr/...)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *publicURL == "" {
+		host := *addr
+		if strings.HasPrefix(host, ":") {
+			host = "localhost" + host
+		}
+		*publicURL = "http://" + host
+	}
+
+	srv := newServer(strings.TrimRight(*publicURL, "/"), *login, *password, *callbackKey)
+	srv.experimentalQR = *experimentalQR
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           srv,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log.Printf("песочница шлюза: %s/payment/ (REST: %s/payment/rest/)", srv.publicURL, srv.publicURL)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	money "github.com/bsagat/bereke-merchant-api/currency"
+	"github.com/bsagat/bereke-merchant-api/models/code"
+)
+
+// Исходы оплаты на платёжной странице.
+const (
+	outcomeSuccess = "success" // успешная оплата
+	outcomeDecline = "decline" // отказ с выбранным actionCode
+	outcome3DS     = "3ds"     // переход на страницу ACS (3-D Secure)
+	outcomeTimeout = "timeout" // истечение срока оплаты (PaymentTimeout)
+)
+
+// declineCodes — коды отказа, доступные для выбора на платёжной странице.
+var declineCodes = []code.ActionCode{
+	code.InsufficientFunds,
+	code.IssuerDeclined,
+	code.CardExpired,
+	code.InvalidCVC,
+	code.InvalidCardNumber,
+	code.CardBlocked,
+	code.ExceededCardLimit,
+	code.SecurityViolation,
+	code.TooManyPaymentAttempts,
+	code.LimitBlock,
+	code.CardDeclinedUnknown,
+}
+
+type declineOption struct {
+	Code        int
+	Description string
+}
+
+type pageData struct {
+	Order    order
+	Amount   string
+	Payable  bool
+	Redirect string
+	Codes    []declineOption
+	Submit   string
+	ACS      string
+}
+
+func (s *server) newPageData(o order) pageData {
+	data := pageData{
+		Order:    o,
+		Amount:   strconv.FormatFloat(o.amount(), 'f', 2, 64) + " " + money.ToAlpha(o.Currency),
+		Payable:  payable(&o),
+		Redirect: redirectURL(o),
+		Submit:   paymentRoot + submitPath,
+		ACS:      paymentRoot + acsPath,
+	}
+	for _, c := range declineCodes {
+		data.Codes = append(data.Codes, declineOption{Code: int(c), Description: c.Describe(code.LangRu).Merchant})
+	}
+	return data
+}
+
+// servePage — платёжная страница заказа (formUrl).
+func (s *server) servePage(w http.ResponseWriter, r *http.Request) {
+	o, ok := s.store.get(r.URL.Query().Get("mdOrder"))
+	if !ok {
+		http.Error(w, "Заказ не найден", http.StatusNotFound)
+		return
+	}
+	render(w, paymentPage, s.newPageData(o))
+}
+
+// serveSubmit — обработка исхода, выбранного на платёжной странице.
+func (s *server) serveSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Некорректные параметры формы", http.StatusBadRequest)
+		return
+	}
+
+	orderID := r.PostForm.Get("mdOrder")
+	card := cardData{PAN: r.PostForm.Get("pan"), CardholderName: r.PostForm.Get("cardholder")}
+
+	var (
+		o   order
+		err error
+	)
+	switch r.PostForm.Get("outcome") {
+	case outcomeSuccess:
+		o, err = s.approve(orderID, card)
+	case outcomeDecline:
+		action, convErr := strconv.Atoi(r.PostForm.Get("code"))
+		if convErr != nil {
+			http.Error(w, "Некорректный код отказа", http.StatusBadRequest)
+			return
+		}
+		o, err = s.fail(orderID, code.ActionCode(action), card)
+	case outcome3DS:
+		if _, err = s.challenge(orderID, card); err == nil {
+			http.Redirect(w, r, paymentRoot+acsPath+"?mdOrder="+url.QueryEscape(orderID), http.StatusSeeOther)
+			return
+		}
+	case outcomeTimeout:
+		o, err = s.fail(orderID, code.PaymentTimeout, card)
+	default:
+		http.Error(w, "Неизвестный исход оплаты", http.StatusBadRequest)
+		return
+	}
+
+	s.finish(w, r, orderID, o, err)
+}
+
+// serveACS — эмуляция страницы ACS банка-эмитента: подтверждение или отказ 3-D Secure.
+func (s *server) serveACS(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		o, ok := s.store.get(r.URL.Query().Get("mdOrder"))
+		if !ok {
+			http.Error(w, "Заказ не найден", http.StatusNotFound)
+			return
+		}
+		render(w, acsPage, s.newPageData(o))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Некорректные параметры формы", http.StatusBadRequest)
+		return
+	}
+
+	orderID := r.PostForm.Get("mdOrder")
+	if orderID == "" {
+		// 3DS2: форма RenderACSForm передаёт packedCReq в поле creq
+		orderID = r.PostForm.Get("creq")
+	}
+	o, ok := s.store.get(orderID)
+	if !ok {
+		http.Error(w, "Заказ не найден", http.StatusNotFound)
+		return
+	}
+
+	result := r.PostForm.Get("result")
+	switch {
+	case result == "":
+		render(w, acsPage, s.newPageData(o))
+		return
+	case o.ThreeDSTransID != "":
+		s.completeChallenge(w, r, orderID, result == "confirm")
+		return
+	}
+
+	var err error
+	if result == "confirm" {
+		o, err = s.approve(orderID, cardData{})
+	} else {
+		o, err = s.fail(orderID, code.DSecureFailed, cardData{})
+	}
+
+	s.finish(w, r, orderID, o, err)
+}
+
+// finish — перенаправление покупателя на returnUrl/failUrl. Если заказ уже оплачен
+// или отклонён, покупатель возвращается на платёжную страницу с текущим статусом.
+func (s *server) finish(w http.ResponseWriter, r *http.Request, orderID string, o order, err error) {
+	if err != nil {
+		http.Redirect(w, r, s.pageURL(orderID), http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, redirectURL(o), http.StatusSeeOther)
+}
+
+func render(w http.ResponseWriter, t *template.Template, data pageData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := t.Execute(w, data); err != nil {
+		log.Printf("render page: %v", err)
+	}
+}
+
+const pageStyle = `<style>
+body { font-family: sans-serif; max-width: 480px; margin: 40px auto; color: #222; }
+fieldset { margin: 12px 0; border: 1px solid #ccc; }
+label { display: block; margin: 6px 0; }
+input[type=text] { width: 100%; box-sizing: border-box; }
+button { padding: 8px 16px; margin-right: 8px; }
+.note { color: #666; font-size: 0.9em; }
+</style>`
+
+var paymentPage = template.Must(template.New("payment").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head><meta charset="utf-8"><title>Песочница Bereke — оплата заказа {{.Order.Number}}</title>` + pageStyle + `</head>
+<body>
+<h1>Оплата заказа</h1>
+<p>Номер заказа: <b>{{.Order.Number}}</b><br>
+ID в шлюзе: {{.Order.ID}}<br>
+Сумма: <b>{{.Amount}}</b>{{if .Order.PreAuth}} (предавторизация){{end}}</p>
+{{with .Order.Description}}<p>{{.}}</p>{{end}}
+{{if .Payable}}
+<form method="post" action="{{.Submit}}">
+<input type="hidden" name="mdOrder" value="{{.Order.ID}}">
+<fieldset><legend>Карта</legend>
+<label>Номер карты <input type="text" name="pan" value="4111111111111111"></label>
+<label>Держатель карты <input type="text" name="cardholder" value="SANDBOX TESTER"></label>
+</fieldset>
+<fieldset><legend>Исход оплаты</legend>
+<label><input type="radio" name="outcome" value="success" checked> Успешная оплата</label>
+<label><input type="radio" name="outcome" value="decline"> Отказ с кодом
+<select name="code">{{range .Codes}}<option value="{{.Code}}">{{.Code}} — {{.Description}}</option>{{end}}</select></label>
+<label><input type="radio" name="outcome" value="3ds"> 3-D Secure (страница ACS)</label>
+<label><input type="radio" name="outcome" value="timeout"> Истечение срока оплаты</label>
+</fieldset>
+<button type="submit">Оплатить</button>
+</form>
+<p class="note">Это песочница: деньги не списываются. После оплаты отправляется колбэк и выполняется переход на returnUrl/failUrl.</p>
+{{else}}
+<p>Статус заказа: {{printf "%d" .Order.Status}}, actionCode {{printf "%d" .Order.ActionCode}}.</p>
+<p><a href="{{.Redirect}}">Вернуться в магазин</a></p>
+{{end}}
+</body>
+</html>`))
+
+var acsPage = template.Must(template.New("acs").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head><meta charset="utf-8"><title>Песочница Bereke — 3-D Secure</title>` + pageStyle + `</head>
+<body>
+<h1>Подтверждение платежа (3-D Secure)</h1>
+<p>Заказ {{.Order.Number}}, сумма <b>{{.Amount}}</b>, карта {{.Order.MaskedPan}}.</p>
+{{if .Payable}}
+<form method="post" action="{{.ACS}}">
+<input type="hidden" name="mdOrder" value="{{.Order.ID}}">
+<button type="submit" name="result" value="confirm">Подтвердить</button>
+<button type="submit" name="result" value="reject">Отклонить</button>
+</form>
+{{else}}
+<p><a href="{{.Redirect}}">Вернуться в магазин</a></p>
+{{end}}
+</body>
+</html>`))
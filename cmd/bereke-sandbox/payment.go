@@ -0,0 +1,232 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/models/code"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+// errRejected — операция невозможна в текущем состоянии заказа.
+var errRejected = errors.New("operation is not allowed in the current order state")
+
+// cardData — данные карты, которыми оплачен заказ.
+type cardData struct {
+	PAN            string
+	CardholderName string
+}
+
+// payable — заказ ожидает оплаты (в том числе после начала 3-D Secure).
+func payable(o *order) bool {
+	return o.Status == types.OrderStatusRegistered || o.Status == types.OrderStatusPending
+}
+
+// approve — успешная оплата: двухстадийный заказ авторизуется, одностадийный списывается.
+func (s *server) approve(orderID string, card cardData) (order, error) {
+	o, ok, err := s.store.update(orderID, func(o *order) error {
+		if !payable(o) {
+			return errRejected
+		}
+
+		now := time.Now()
+		o.ActionCode = code.Success
+		o.ApprovedAmount = o.Amount
+		o.Authorized = now
+		o.ApprovalCode = randomDigits(6)
+		o.AuthRefNum = randomDigits(12)
+		setCard(o, card)
+
+		if o.PreAuth {
+			o.Status = types.OrderStatusAuthorized
+		} else {
+			o.Status = types.OrderStatusCompleted
+			o.DepositedAmount = o.Amount
+			o.Deposited = now
+		}
+		return nil
+	})
+	if !ok {
+		return order{}, errRejected
+	}
+	if err != nil {
+		return o, err
+	}
+
+	s.notify(o, paymentOperation(o), true)
+	return o, nil
+}
+
+// fail — отказ в оплате с кодом action. PaymentTimeout отправляет колбэк declinedByTimeout.
+func (s *server) fail(orderID string, action code.ActionCode, card cardData) (order, error) {
+	o, ok, err := s.store.update(orderID, func(o *order) error {
+		if !payable(o) {
+			return errRejected
+		}
+		o.Status = types.OrderStatusDeclined
+		o.ActionCode = action
+		setCard(o, card)
+		return nil
+	})
+	if !ok {
+		return order{}, errRejected
+	}
+	if err != nil {
+		return o, err
+	}
+
+	if action == code.PaymentTimeout {
+		s.notify(o, types.CallbackDeclinedByTimeout, true)
+	} else {
+		s.notify(o, paymentOperation(o), false)
+	}
+	return o, nil
+}
+
+// challenge — начало 3-D Secure: заказ ожидает ответа ACS.
+func (s *server) challenge(orderID string, card cardData) (order, error) {
+	o, ok, err := s.store.update(orderID, func(o *order) error {
+		if !payable(o) {
+			return errRejected
+		}
+		o.Status = types.OrderStatusPending
+		setCard(o, card)
+		return nil
+	})
+	if !ok {
+		return order{}, errRejected
+	}
+	return o, err
+}
+
+// walletRequest — поля запросов applepay/payment.do и google/payment.do, которые учитывает песочница.
+type walletRequest struct {
+	Merchant     string `json:"merchant"`
+	OrderNumber  string `json:"orderNumber"`
+	Description  string `json:"description"`
+	PreAuth      bool   `json:"preAuth"`
+	PaymentToken string `json:"paymentToken"`
+	Amount       int    `json:"amount"`
+	CurrencyCode int    `json:"currencyCode"`
+	ReturnURL    string `json:"returnUrl"`
+	FailURL      string `json:"failUrl"`
+}
+
+// serveWallet — оплата Apple Pay / Google Pay: заказ регистрируется и сразу оплачивается.
+func (s *server) serveWallet(w http.ResponseWriter, r *http.Request, endpoint string) {
+	var req walletRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeWalletError(w, code.ErrorInvalidParameter, "Некорректный JSON запроса")
+		return
+	}
+	if s.login != "" && req.Merchant != s.login {
+		writeWalletError(w, code.ErrorInvalidParameter, "Доступ запрещён")
+		return
+	}
+	if req.OrderNumber == "" || req.PaymentToken == "" {
+		writeWalletError(w, code.ErrorMissingParameter, "Не указан номер заказа или платёжный токен")
+		return
+	}
+
+	created, err := s.store.create(order{
+		Number:      req.OrderNumber,
+		Amount:      req.Amount,
+		Currency:    req.CurrencyCode,
+		Description: req.Description,
+		PreAuth:     req.PreAuth,
+		ReturnURL:   req.ReturnURL,
+		FailURL:     req.FailURL,
+		ActionCode:  code.WaitingForPaymentAttempt,
+	})
+	if err == errDuplicateOrder {
+		writeWalletError(w, code.ErrorInvalidOrderNumber, err.Error())
+		return
+	}
+	if err != nil {
+		writeWalletError(w, code.ErrorSystem, err.Error())
+		return
+	}
+
+	pan := "4111110000001111"
+	if endpoint == googlePath {
+		pan = "5555550000004444"
+	}
+	if _, err := s.approve(created.ID, cardData{PAN: pan}); err != nil {
+		writeWalletError(w, code.ErrorSystem, err.Error())
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"success": true,
+		"data":    map[string]string{"orderId": created.ID},
+	})
+}
+
+func writeWalletError(w http.ResponseWriter, c code.ErrorCode, message string) {
+	err := newGatewayError(c, message)
+	writeJSON(w, map[string]interface{}{
+		"success": false,
+		"error":   map[string]string{"code": err.ErrorCode, "message": err.ErrorMessage},
+	})
+}
+
+// paymentOperation — операция колбэка для результата оплаты.
+func paymentOperation(o order) types.CallbackOperation {
+	if o.PreAuth {
+		return types.CallbackApproved
+	}
+	return types.CallbackDeposited
+}
+
+// redirectURL — адрес возврата покупателя: returnUrl при успешной оплате,
+// иначе failUrl (или returnUrl, если failUrl не задан). Шлюз добавляет к адресу orderId.
+func redirectURL(o order) string {
+	target := o.ReturnURL
+	if o.Status != types.OrderStatusAuthorized && o.Status != types.OrderStatusCompleted && o.FailURL != "" {
+		target = o.FailURL
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+	query := u.Query()
+	query.Set("orderId", o.ID)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// setCard — маскированные данные карты в заказе.
+func setCard(o *order, card cardData) {
+	if card.PAN == "" {
+		return
+	}
+	o.MaskedPan = maskPAN(card.PAN)
+	o.CardholderName = card.CardholderName
+}
+
+// maskPAN — номер карты в формате шлюза: первые 6 и последние 4 цифры (400000**0002).
+func maskPAN(pan string) string {
+	if len(pan) < 10 {
+		return "**" + pan
+	}
+	return pan[:6] + "**" + pan[len(pan)-4:]
+}
+
+// randomDigits — случайная строка из n цифр (код авторизации, RRN).
+func randomDigits(n int) string {
+	digits := make([]byte, n)
+	for i := range digits {
+		d, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			d = big.NewInt(0)
+		}
+		digits[i] = byte('0' + d.Int64())
+	}
+	return string(digits)
+}
@@ -0,0 +1,417 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+	money "github.com/bsagat/bereke-merchant-api/currency"
+	"github.com/bsagat/bereke-merchant-api/models/code"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+// Тестовые карты paymentorder.do.
+const (
+	declineCard   = "4000000000000002" // оплата отклоняется с кодом InsufficientFunds
+	challengeCard = "4000000000003220" // оплата требует 3-D Secure 2 с challenge
+)
+
+// terminalID — ID терминала песочницы в ответах getOrderStatusExtended.do.
+const terminalID = "SANDBOX01"
+
+var errInvalidState = newGatewayError(code.ErrorSystem, "Заказ находится в неверном состоянии для операции")
+
+func (s *server) serveREST(w http.ResponseWriter, r *http.Request, endpoint string) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, newGatewayError(code.ErrorInvalidParameter, "Некорректные параметры запроса"))
+		return
+	}
+	if !s.authorized(r.Form) {
+		writeJSON(w, newGatewayError(code.ErrorInvalidParameter, "Доступ запрещён"))
+		return
+	}
+
+	switch endpoint {
+	case "register.do":
+		s.register(w, r.Form, false)
+	case "registerPreAuth.do":
+		s.register(w, r.Form, true)
+	case "getOrderStatusExtended.do":
+		s.orderStatus(w, r.Form)
+	case "deposit.do":
+		s.deposit(w, r.Form)
+	case "reverse.do":
+		s.reverse(w, r.Form)
+	case "refund.do":
+		s.refund(w, r.Form)
+	case "decline.do":
+		s.decline(w, r.Form)
+	case "paymentorder.do":
+		s.paymentOrder(w, r.Form)
+	case "finishThreeDs.do":
+		s.finishThreeDS(w, r.Form)
+	case "verifyEnrollment.do":
+		s.verifyEnrollment(w, r.Form)
+	case bereke.DefaultQREndpoints.Dynamic:
+		if !s.experimentalQR {
+			http.NotFound(w, r)
+			return
+		}
+		s.qrDynamic(w, r.Form)
+	case bereke.DefaultQREndpoints.Status:
+		if !s.experimentalQR {
+			http.NotFound(w, r)
+			return
+		}
+		s.qrStatus(w, r.Form)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// register — register.do и registerPreAuth.do.
+func (s *server) register(w http.ResponseWriter, form url.Values, preAuth bool) {
+	if form.Get("orderNumber") == "" {
+		writeJSON(w, newGatewayError(code.ErrorMissingParameter, "Не указан номер заказа"))
+		return
+	}
+	if form.Get("returnUrl") == "" {
+		writeJSON(w, newGatewayError(code.ErrorMissingParameter, "Не указан URL возврата"))
+		return
+	}
+	amount, err := strconv.Atoi(form.Get("amount"))
+	if err != nil || amount <= 0 {
+		writeJSON(w, newGatewayError(code.ErrorInvalidParameter, "Неверная сумма"))
+		return
+	}
+	currency, ok := parseCurrency(form.Get("currency"))
+	if !ok {
+		writeJSON(w, newGatewayError(code.ErrorUnknownCurrency, ""))
+		return
+	}
+
+	o, err := s.store.create(order{
+		Number:      form.Get("orderNumber"),
+		Amount:      amount,
+		Currency:    currency,
+		Description: form.Get("description"),
+		PreAuth:     preAuth,
+		ReturnURL:   form.Get("returnUrl"),
+		FailURL:     form.Get("failUrl"),
+		CallbackURL: form.Get("dynamicCallbackUrl"),
+		ActionCode:  code.WaitingForPaymentAttempt,
+	})
+	if err == errDuplicateOrder {
+		writeJSON(w, newGatewayError(code.ErrorInvalidOrderNumber, err.Error()))
+		return
+	}
+	if err != nil {
+		writeJSON(w, newGatewayError(code.ErrorSystem, err.Error()))
+		return
+	}
+
+	writeJSON(w, map[string]string{"orderId": o.ID, "formUrl": s.pageURL(o.ID)})
+}
+
+// statusResponse — ответ getOrderStatusExtended.do.
+type statusResponse struct {
+	gatewayError
+
+	OrderNumber           string `json:"orderNumber"`
+	OrderID               string `json:"orderId"`
+	OrderStatus           int    `json:"orderStatus"`
+	ActionCode            int    `json:"actionCode"`
+	ActionCodeDescription string `json:"actionCodeDescription"`
+	Amount                int    `json:"amount"`
+	Currency              string `json:"currency"`
+	AuthRefNum            string `json:"authRefNum,omitempty"`
+	TerminalID            string `json:"terminalId"`
+
+	Date          int64 `json:"date"`
+	AuthDateTime  int64 `json:"authDateTime,omitempty"`
+	DepositedDate int64 `json:"depositedDate,omitempty"`
+	ReversedDate  int64 `json:"reversedDate,omitempty"`
+	RefundedDate  int64 `json:"refundedDate,omitempty"`
+
+	PaymentAmountInfo struct {
+		ApprovedAmount  int    `json:"approvedAmount"`
+		DepositedAmount int    `json:"depositedAmount"`
+		RefundedAmount  int    `json:"refundedAmount"`
+		PaymentState    string `json:"paymentState"`
+	} `json:"paymentAmountInfo"`
+
+	CardAuthInfo *cardAuthInfo `json:"cardAuthInfo,omitempty"`
+}
+
+type cardAuthInfo struct {
+	MaskedPan      string `json:"maskedPan"`
+	CardholderName string `json:"cardholderName,omitempty"`
+	ApprovalCode   string `json:"approvalCode,omitempty"`
+}
+
+// orderStatus — getOrderStatusExtended.do (по orderId или orderNumber).
+func (s *server) orderStatus(w http.ResponseWriter, form url.Values) {
+	o, ok := s.store.find(form.Get("orderId"), form.Get("orderNumber"))
+	if !ok {
+		writeJSON(w, newGatewayError(code.ErrorOrderNotFound, ""))
+		return
+	}
+
+	resp := statusResponse{
+		gatewayError:          newGatewayError(code.ErrorNone, "Успешно"),
+		OrderNumber:           o.Number,
+		OrderID:               o.ID,
+		OrderStatus:           int(o.Status),
+		ActionCode:            int(o.ActionCode),
+		ActionCodeDescription: o.ActionCode.Describe(code.LangRu).Merchant,
+		Amount:                o.Amount,
+		Currency:              strconv.Itoa(o.Currency),
+		AuthRefNum:            o.AuthRefNum,
+		TerminalID:            terminalID,
+		Date:                  millis(o.Created),
+		AuthDateTime:          millis(o.Authorized),
+		DepositedDate:         millis(o.Deposited),
+		ReversedDate:          millis(o.Reversed),
+		RefundedDate:          millis(o.Refunded),
+	}
+	resp.PaymentAmountInfo.ApprovedAmount = o.ApprovedAmount
+	resp.PaymentAmountInfo.DepositedAmount = o.DepositedAmount
+	resp.PaymentAmountInfo.RefundedAmount = o.RefundedAmount
+	resp.PaymentAmountInfo.PaymentState = string(o.paymentState())
+	if o.MaskedPan != "" {
+		resp.CardAuthInfo = &cardAuthInfo{MaskedPan: o.MaskedPan, CardholderName: o.CardholderName, ApprovalCode: o.ApprovalCode}
+	}
+
+	writeJSON(w, resp)
+}
+
+// deposit — deposit.do: списание предавторизованной суммы (amount = 0 — вся сумма).
+func (s *server) deposit(w http.ResponseWriter, form url.Values) {
+	amount, _ := strconv.Atoi(form.Get("amount"))
+
+	s.operation(w, form.Get("orderId"), types.CallbackDeposited, func(o *order) *gatewayError {
+		if o.Status != types.OrderStatusAuthorized {
+			return &errInvalidState
+		}
+		if amount == 0 {
+			amount = o.ApprovedAmount
+		}
+		if amount < 0 || amount > o.ApprovedAmount {
+			err := newGatewayError(code.ErrorInvalidParameter, "Неверная сумма")
+			return &err
+		}
+		o.Status = types.OrderStatusCompleted
+		o.DepositedAmount = amount
+		o.Deposited = time.Now()
+		return nil
+	})
+}
+
+// reverse — reverse.do: снятие блокировки по предавторизованному заказу.
+func (s *server) reverse(w http.ResponseWriter, form url.Values) {
+	s.operation(w, form.Get("orderId"), types.CallbackReversed, func(o *order) *gatewayError {
+		if o.Status != types.OrderStatusAuthorized {
+			return &errInvalidState
+		}
+		o.Status = types.OrderStatusCancelled
+		o.Reversed = time.Now()
+		return nil
+	})
+}
+
+// refund — refund.do: полный или частичный возврат списанной суммы.
+func (s *server) refund(w http.ResponseWriter, form url.Values) {
+	amount, err := strconv.Atoi(form.Get("amount"))
+
+	s.operation(w, form.Get("orderId"), types.CallbackRefunded, func(o *order) *gatewayError {
+		if o.Status != types.OrderStatusCompleted && o.Status != types.OrderStatusRefunded {
+			return &errInvalidState
+		}
+		if err != nil || amount <= 0 || amount > o.DepositedAmount-o.RefundedAmount {
+			err := newGatewayError(code.ErrorInvalidParameter, "Неверная сумма возврата")
+			return &err
+		}
+		o.Status = types.OrderStatusRefunded
+		o.RefundedAmount += amount
+		o.LastRefundAmount = amount
+		o.Refunded = time.Now()
+		return nil
+	})
+}
+
+// decline — decline.do: отмена неоплаченного заказа. Колбэк не отправляется.
+func (s *server) decline(w http.ResponseWriter, form url.Values) {
+	s.operation(w, form.Get("orderId"), "", func(o *order) *gatewayError {
+		if o.Status != types.OrderStatusRegistered && o.Status != types.OrderStatusPending {
+			return &errInvalidState
+		}
+		o.Status = types.OrderStatusDeclined
+		o.ActionCode = code.OperationNotAllowed
+		return nil
+	})
+}
+
+// operation — изменение состояния заказа с ответом шлюза и колбэком operation
+// (пустая operation — без колбэка).
+func (s *server) operation(w http.ResponseWriter, orderID string, operation types.CallbackOperation, fn func(o *order) *gatewayError) {
+	var failure *gatewayError
+	o, ok, _ := s.store.update(orderID, func(o *order) error {
+		if failure = fn(o); failure != nil {
+			return errRejected
+		}
+		return nil
+	})
+	switch {
+	case !ok:
+		writeJSON(w, newGatewayError(code.ErrorOrderNotFound, ""))
+	case failure != nil:
+		writeJSON(w, *failure)
+	default:
+		if operation != "" {
+			s.notify(o, operation, true)
+		}
+		writeJSON(w, newGatewayError(code.ErrorNone, "Успешно"))
+	}
+}
+
+// paymentOrder — paymentorder.do: оплата заказа картой без платёжной страницы.
+// Карта declineCard отклоняется, challengeCard начинает 3-D Secure 2 (см. threeds.go),
+// остальные проходят без 3-D Secure. Повторный вызов с threeDSServerTransId продолжает 3DS2.
+func (s *server) paymentOrder(w http.ResponseWriter, form url.Values) {
+	orderID := form.Get("MDORDER")
+	if _, ok := s.store.get(orderID); !ok {
+		writeJSON(w, newGatewayError(code.ErrorOrderNotFound, ""))
+		return
+	}
+	if transID := form.Get("threeDSServerTransId"); transID != "" {
+		s.continueThreeDS2(w, orderID, transID, form.Get("threeDSVer2FinishUrl"))
+		return
+	}
+
+	card := cardData{PAN: form.Get("$PAN"), CardholderName: form.Get("TEXT")}
+	if card.PAN == "" {
+		writeJSON(w, newGatewayError(code.ErrorMissingParameter, "Не указан номер карты"))
+		return
+	}
+
+	var (
+		o   order
+		err error
+	)
+	switch card.PAN {
+	case declineCard:
+		o, err = s.fail(orderID, code.InsufficientFunds, card)
+	case challengeCard:
+		s.startThreeDS2(w, orderID, card)
+		return
+	default:
+		o, err = s.approve(orderID, card)
+	}
+	writePaymentResult(w, o, err)
+}
+
+// writePaymentResult — ответ paymentorder.do/finishThreeDs.do с адресом возврата покупателя.
+func writePaymentResult(w http.ResponseWriter, o order, err error) {
+	if err != nil {
+		writeJSON(w, errInvalidState)
+		return
+	}
+	writeJSON(w, map[string]string{
+		"errorCode": "0",
+		"redirect":  redirectURL(o),
+		"info":      "Ваш платёж обработан, происходит переадресация...",
+	})
+}
+
+// verifyEnrollment — verifyEnrollment.do: карты песочницы не вовлечены в 3-D Secure.
+func (s *server) verifyEnrollment(w http.ResponseWriter, form url.Values) {
+	if form.Get("pan") == "" {
+		writeJSON(w, newGatewayError(code.ErrorMissingParameter, "Не указан номер карты"))
+		return
+	}
+	writeJSON(w, map[string]string{
+		"errorCode":          "0",
+		"errorMessage":       "Успешно",
+		"enrolled":           "N",
+		"emitterName":        "SANDBOX BANK",
+		"emitterCountryCode": "KZ",
+	})
+}
+
+// qrDynamic — экспериментальный sbp/c2b/qr/dynamic/get.do (--experimental-qr). Payload QR-кода — ссылка на платёжную страницу.
+func (s *server) qrDynamic(w http.ResponseWriter, form url.Values) {
+	orderID := form.Get("mdOrder")
+	qrID, err := s.store.ids.Next()
+	if err != nil {
+		writeJSON(w, newGatewayError(code.ErrorSystem, err.Error()))
+		return
+	}
+
+	_, ok, err := s.store.update(orderID, func(o *order) error {
+		if o.Status != types.OrderStatusRegistered {
+			return errRejected
+		}
+		o.QRID = qrID
+		return nil
+	})
+	switch {
+	case !ok:
+		writeJSON(w, newGatewayError(code.ErrorOrderNotFound, ""))
+	case err != nil:
+		writeJSON(w, errInvalidState)
+	default:
+		writeJSON(w, map[string]string{
+			"errorCode": "0",
+			"qrId":      qrID,
+			"payload":   s.pageURL(orderID),
+			"qrStatus":  string(types.QRStarted),
+		})
+	}
+}
+
+// qrStatus — экспериментальный sbp/c2b/qr/status.do (--experimental-qr): статус QR-кода по состоянию заказа.
+func (s *server) qrStatus(w http.ResponseWriter, form url.Values) {
+	o, ok := s.store.get(form.Get("mdOrder"))
+	if !ok || o.QRID == "" || o.QRID != form.Get("qrId") {
+		writeJSON(w, newGatewayError(code.ErrorOrderNotFound, ""))
+		return
+	}
+
+	status := types.QRStarted
+	switch o.Status {
+	case types.OrderStatusPending:
+		status = types.QRConfirmed
+	case types.OrderStatusAuthorized, types.OrderStatusCompleted, types.OrderStatusCancelled, types.OrderStatusRefunded:
+		status = types.QRAccepted
+	case types.OrderStatusDeclined:
+		status = types.QRRejected
+	}
+
+	writeJSON(w, map[string]string{
+		"errorCode":        "0",
+		"qrStatus":         string(status),
+		"transactionState": string(o.paymentState()),
+	})
+}
+
+// parseCurrency — числовой код валюты из параметра currency (пусто — тенге).
+func parseCurrency(value string) (int, bool) {
+	if value == "" {
+		return money.ToNumeric(money.KZT), true
+	}
+	currency, err := strconv.Atoi(value)
+	if err != nil || money.ToAlpha(currency) == "" {
+		return 0, false
+	}
+	return currency, true
+}
+
+// millis — время в миллисекундах Unix (0 — время не задано).
+func millis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
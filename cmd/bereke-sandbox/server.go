@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/bsagat/bereke-merchant-api/models/code"
+)
+
+// Пути песочницы относительно /payment/ — так же, как у шлюза.
+const (
+	restPrefix  = "rest/"
+	pagePath    = "merchants/sandbox/payment_ru.html"
+	submitPath  = "merchants/sandbox/submit"
+	acsPath     = "merchants/sandbox/acs"
+	applePath   = "applepay/payment.do"
+	googlePath  = "google/payment.do"
+	paymentRoot = "/payment/"
+)
+
+// server — HTTP-обработчик песочницы.
+type server struct {
+	store       *store
+	publicURL   string
+	login       string
+	password    string
+	callbackKey string // секрет HMAC-SHA256 для checksum колбэков (пусто — без подписи)
+	callbacks   *http.Client
+
+	experimentalQR bool // эмулировать экспериментальные endpoint'ы QR-кодов
+}
+
+func newServer(publicURL, login, password, callbackKey string) *server {
+	return &server{
+		store:       newStore(),
+		publicURL:   publicURL,
+		login:       login,
+		password:    password,
+		callbackKey: callbackKey,
+		callbacks:   &http.Client{Timeout: callbackTimeout},
+	}
+}
+
+// ServeHTTP — маршрутизация по очищенному пути.
+// Клиент формирует адреса вида /payment/rest//register.do, а http.ServeMux
+// ответил бы на них редиректом, который теряет тело POST-запроса.
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := path.Clean("/" + r.URL.Path)
+	if !strings.HasPrefix(p, paymentRoot) {
+		http.NotFound(w, r)
+		return
+	}
+	p = strings.TrimPrefix(p, paymentRoot)
+
+	switch {
+	case strings.HasPrefix(p, restPrefix):
+		s.serveREST(w, r, strings.TrimPrefix(p, restPrefix))
+	case p == applePath || p == googlePath:
+		s.serveWallet(w, r, p)
+	case p == pagePath:
+		s.servePage(w, r)
+	case p == submitPath:
+		s.serveSubmit(w, r)
+	case p == acsPath:
+		s.serveACS(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// authorized — проверка учётных данных мерчанта.
+// Если логин песочницы не задан, принимаются любые userName/password или token.
+func (s *server) authorized(form url.Values) bool {
+	if s.login == "" {
+		return true
+	}
+	return form.Get("userName") == s.login && form.Get("password") == s.password
+}
+
+// pageURL — адрес платёжной страницы заказа (formUrl).
+func (s *server) pageURL(orderID string) string {
+	return s.publicURL + paymentRoot + pagePath + "?mdOrder=" + url.QueryEscape(orderID)
+}
+
+// gatewayError — ответ шлюза с ошибкой.
+type gatewayError struct {
+	ErrorCode    string `json:"errorCode"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+func newGatewayError(c code.ErrorCode, message string) gatewayError {
+	if message == "" {
+		message = c.Describe(code.LangRu).Merchant
+	}
+	return gatewayError{ErrorCode: strconv.Itoa(int(c)), ErrorMessage: message}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("write response: %v", err)
+	}
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+	"github.com/bsagat/bereke-merchant-api/webhook"
+)
+
+// newSandbox — песочница на httptest-сервере и клиент, подключённый к ней.
+func newSandbox(t *testing.T, callbackKey string, opts ...bereke.Option) (*server, bereke.API) {
+	t.Helper()
+	srv := newServer("", "", "", callbackKey)
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	srv.publicURL = ts.URL
+
+	api, err := bereke.NewWithToken("token", types.TEST, append(opts, bereke.WithGatewayURL(ts.URL+"/payment/"))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, api
+}
+
+// browser — HTTP-клиент без перехода по редиректам: тест проверяет сам адрес перенаправления.
+var browser = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+}
+
+func register(t *testing.T, api bereke.API, number, callbackURL string) core.RegisterOrderResponse {
+	t.Helper()
+	resp, err := api.RegisterOrder(context.Background(), core.RegisterOrderRequest{
+		Order: core.Order{
+			OrderNumber: number,
+			Amount:      1500,
+			Currency:    398,
+			ReturnURL:   "https://shop.example.com/ok",
+			FailURL:     "https://shop.example.com/fail",
+		},
+		DynamicCallbackURL: callbackURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.ErrorCode.IsSuccess() || resp.OrderID == "" || resp.FormURL == "" {
+		t.Fatalf("register: %+v", resp)
+	}
+	return resp
+}
+
+func TestSignedCallbacks(t *testing.T) {
+	const key = "callback-secret"
+	_, api := newSandbox(t, key)
+
+	events := make(chan webhook.Event, 4)
+	handler := webhook.New(api, func(_ context.Context, event webhook.Event) error {
+		events <- event
+		return nil
+	}, webhook.Config{
+		HMACKey: key,
+		OnError: func(_ *http.Request, status int, err error) {
+			t.Errorf("callback rejected with %d: %v", status, err)
+		},
+	})
+	merchant := httptest.NewServer(handler)
+	defer merchant.Close()
+
+	order := register(t, api, "A-1", merchant.URL)
+	resp, err := browser.PostForm(merchantURL(order.FormURL, submitPath), url.Values{
+		"mdOrder": {order.OrderID},
+		"pan":     {"4111111111111111"},
+		"outcome": {outcomeSuccess},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if _, err := api.RefundOrder(context.Background(), core.RefundOrderRequest{OrderID: order.OrderID, Amount: 500}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []struct {
+		operation types.CallbackOperation
+		amount    int64
+	}{
+		{types.CallbackDeposited, 150000},
+		{types.CallbackRefunded, 50000},
+	} {
+		select {
+		case event := <-events:
+			if event.Operation != want.operation || event.Amount != want.amount || !event.Success {
+				t.Errorf("callback = %s %d (success %t), want %s %d", event.Operation, event.Amount, event.Success, want.operation, want.amount)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s callback was not delivered", want.operation)
+		}
+	}
+}
+
+// merchantURL — адрес страницы песочницы на том же хосте, что и formUrl.
+func merchantURL(formURL, page string) string {
+	u, _ := url.Parse(formURL)
+	u.Path, u.RawQuery = paymentRoot+page, ""
+	return u.String()
+}
+
+func TestPaymentPage(t *testing.T) {
+	_, api := newSandbox(t, "")
+	order := register(t, api, "A-2", "")
+
+	page, err := browser.Get(order.FormURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page.Body.Close()
+	if page.StatusCode != http.StatusOK {
+		t.Fatalf("payment page: status %d", page.StatusCode)
+	}
+
+	resp, err := browser.PostForm(merchantURL(order.FormURL, submitPath), url.Values{
+		"mdOrder": {order.OrderID},
+		"pan":     {"4111111111111111"},
+		"outcome": {outcomeSuccess},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if want := "https://shop.example.com/ok?orderId=" + order.OrderID; resp.Header.Get("Location") != want {
+		t.Errorf("redirect = %q, want %q", resp.Header.Get("Location"), want)
+	}
+
+	status, err := api.GetOrderStatusByID(context.Background(), order.OrderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.OrderStatus != types.OrderStatusCompleted || status.PaymentAmountInfo.DepositedAmount != 150000 {
+		t.Errorf("status = %d, deposited = %d; want completed, 150000", status.OrderStatus, status.PaymentAmountInfo.DepositedAmount)
+	}
+	if status.CardInfo.MaskedPan != "411111**1111" {
+		t.Errorf("masked pan = %q", status.CardInfo.MaskedPan)
+	}
+}
+
+func TestThreeDS2(t *testing.T) {
+	tests := []struct {
+		result     string
+		wantStatus types.OrderStatus
+		wantURL    string
+	}{
+		{"confirm", types.OrderStatusCompleted, "https://shop.example.com/ok"},
+		{"reject", types.OrderStatusDeclined, "https://shop.example.com/fail"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.result, func(t *testing.T) {
+			ctx := context.Background()
+			_, api := newSandbox(t, "")
+			order := register(t, api, "A-3", "")
+			card := core.CardPaymentRequest{OrderID: order.OrderID, PAN: challengeCard, CVC: "123", Expiry: "209912"}
+
+			// 1. paymentorder.do — карта требует 3DS2
+			first, err := api.PayOrder(ctx, card)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if first.ThreeDS == nil || first.ThreeDS.ThreeDSServerTransID == "" {
+				t.Fatalf("PayOrder: no 3DS2 transaction in %+v", first)
+			}
+			transID := first.ThreeDS.ThreeDSServerTransID
+
+			// 2. paymentorder.do с threeDSServerTransId — challenge на странице ACS
+			second, err := api.ContinueThreeDS2(ctx, core.ThreeDS2Request{
+				CardPaymentRequest:   card,
+				ThreeDSServerTransID: transID,
+				FinishURL:            "https://shop.example.com/3ds/finish",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if second.ThreeDS == nil || second.ThreeDS.ACSUrl == "" || second.ThreeDS.PackedCReq == "" {
+				t.Fatalf("ContinueThreeDS2: no challenge in %+v", second)
+			}
+
+			// 3. Браузер отправляет creq на ACS и подтверждает или отклоняет платёж
+			acs, err := browser.PostForm(second.ThreeDS.ACSUrl, url.Values{"creq": {second.ThreeDS.PackedCReq}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			acs.Body.Close()
+			if acs.StatusCode != http.StatusOK {
+				t.Fatalf("ACS page: status %d", acs.StatusCode)
+			}
+			acs, err = browser.PostForm(second.ThreeDS.ACSUrl, url.Values{"mdOrder": {order.OrderID}, "result": {tt.result}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			acs.Body.Close()
+			if want := "https://shop.example.com/3ds/finish?threeDSServerTransId=" + transID; acs.Header.Get("Location") != want {
+				t.Errorf("ACS redirect = %q, want %q", acs.Header.Get("Location"), want)
+			}
+
+			// 4. finishThreeDs.do завершает оплату
+			finish, err := api.FinishThreeDS(ctx, core.FinishThreeDSRequest{OrderID: order.OrderID, ThreeDSServerTransID: transID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !finish.ErrorCode.IsSuccess() || finish.Redirect != tt.wantURL+"?orderId="+order.OrderID {
+				t.Errorf("FinishThreeDS = %+v, want redirect to %s", finish, tt.wantURL)
+			}
+
+			status, err := api.GetOrderStatusByID(ctx, order.OrderID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.OrderStatus != tt.wantStatus {
+				t.Errorf("order status = %d, want %d", status.OrderStatus, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestExperimentalQR(t *testing.T) {
+	ctx := context.Background()
+	qr := bereke.WithExperimentalQR(bereke.QREndpoints{})
+
+	// Без --experimental-qr неподтверждённые пути QR-кодов неизвестны песочнице
+	_, api := newSandbox(t, "", qr)
+	order := register(t, api, "A-4", "")
+	if _, err := api.GetQRStatus(ctx, core.QRStatusRequest{OrderID: order.OrderID, QRID: "qr"}); err == nil {
+		t.Error("QR status served without --experimental-qr")
+	}
+
+	srv, api := newSandbox(t, "", qr)
+	srv.experimentalQR = true
+	resp, err := api.RegisterQR(ctx, core.QRRequest{RegisterOrderRequest: core.RegisterOrderRequest{Order: core.Order{
+		OrderNumber: "A-5",
+		Amount:      1500,
+		Currency:    398,
+		ReturnURL:   "https://shop.example.com/ok",
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.QRID == "" || len(resp.Image) == 0 {
+		t.Fatalf("RegisterQR = %+v, want QR ID and image", resp)
+	}
+	status, err := api.GetQRStatus(ctx, core.QRStatusRequest{OrderID: resp.OrderID, QRID: resp.QRID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.QRStatus != types.QRStarted {
+		t.Errorf("QR status = %s, want %s", status.QRStatus, types.QRStarted)
+	}
+}
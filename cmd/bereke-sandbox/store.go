@@ -0,0 +1,176 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	money "github.com/bsagat/bereke-merchant-api/currency"
+	"github.com/bsagat/bereke-merchant-api/models/code"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+	"github.com/bsagat/bereke-merchant-api/ordernumber"
+)
+
+var errDuplicateOrder = errors.New("Заказ с таким номером уже обработан")
+
+// order — заказ песочницы. Суммы хранятся в минорных единицах валюты.
+type order struct {
+	ID          string
+	Number      string
+	Amount      int
+	Currency    int
+	Description string
+	PreAuth     bool
+
+	ReturnURL   string
+	FailURL     string
+	CallbackURL string
+
+	Status     types.OrderStatus
+	ActionCode code.ActionCode
+	QRID       string
+
+	// 3-D Secure 2 (paymentorder.do → ACS → finishThreeDs.do)
+	ThreeDSTransID   string // threeDSServerTransId
+	ThreeDSFinishURL string // threeDSVer2FinishUrl: куда ACS возвращает покупателя
+	ThreeDSResult    string // Результат challenge на странице ACS (threeDSConfirmed/threeDSRejected)
+
+	ApprovedAmount   int
+	DepositedAmount  int
+	RefundedAmount   int
+	LastRefundAmount int // Сумма последнего возврата (amount в колбэке refunded)
+
+	MaskedPan      string
+	CardholderName string
+	ApprovalCode   string
+	AuthRefNum     string
+
+	Created    time.Time
+	Authorized time.Time
+	Deposited  time.Time
+	Reversed   time.Time
+	Refunded   time.Time
+}
+
+// paymentState — состояние платежа для paymentAmountInfo.paymentState.
+func (o *order) paymentState() types.PaymentState {
+	switch o.Status {
+	case types.OrderStatusAuthorized:
+		return types.OrderApproved
+	case types.OrderStatusCompleted:
+		return types.OrderDeposited
+	case types.OrderStatusDeclined:
+		return types.OrderDeclined
+	case types.OrderStatusCancelled:
+		return types.OrderReversed
+	case types.OrderStatusRefunded:
+		return types.OrderRefunded
+	default:
+		return types.OrderCreated
+	}
+}
+
+// amount — сумма заказа в основных единицах валюты (для платёжной страницы).
+func (o *order) amount() float64 {
+	return money.ConvertFromMinorUnits(o.Amount, o.Currency)
+}
+
+// store — хранилище заказов в памяти. Заказы живут до перезапуска песочницы.
+type store struct {
+	mu       sync.Mutex
+	ids      ordernumber.Generator
+	byID     map[string]*order
+	byNumber map[string]*order
+}
+
+func newStore() *store {
+	return &store{
+		ids:      ordernumber.UUIDv7(),
+		byID:     map[string]*order{},
+		byNumber: map[string]*order{},
+	}
+}
+
+// create — регистрация заказа. Номер заказа должен быть уникален.
+func (s *store) create(o order) (order, error) {
+	id, err := s.ids.Next()
+	if err != nil {
+		return order{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.byNumber[o.Number]; ok {
+		return order{}, errDuplicateOrder
+	}
+
+	o.ID = id
+	o.Created = time.Now()
+	if o.Currency == 0 {
+		o.Currency = money.ToNumeric(money.KZT)
+	}
+
+	s.byID[o.ID] = &o
+	s.byNumber[o.Number] = &o
+	return o, nil
+}
+
+// get — копия заказа по ID в шлюзе.
+func (s *store) get(id string) (order, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.byID[id]
+	if !ok {
+		return order{}, false
+	}
+	return *o, true
+}
+
+// findTrans — копия заказа по ID транзакции 3-D Secure 2.
+func (s *store) findTrans(transID string) (order, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, o := range s.byID {
+		if transID != "" && o.ThreeDSTransID == transID {
+			return *o, true
+		}
+	}
+	return order{}, false
+}
+
+// find — копия заказа по ID в шлюзе или номеру заказа мерчанта.
+func (s *store) find(id, number string) (order, bool) {
+	if id != "" {
+		return s.get(id)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.byNumber[number]
+	if !ok {
+		return order{}, false
+	}
+	return *o, true
+}
+
+// update — атомарное изменение заказа. Если fn возвращает ошибку, заказ не меняется.
+func (s *store) update(id string, fn func(o *order) error) (order, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.byID[id]
+	if !ok {
+		return order{}, false, nil
+	}
+
+	updated := *o
+	if err := fn(&updated); err != nil {
+		return *o, true, err
+	}
+	*o = updated
+	return updated, true, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/bsagat/bereke-merchant-api/models/code"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+// Результаты challenge на странице ACS.
+const (
+	threeDSConfirmed = "confirmed"
+	threeDSRejected  = "rejected"
+)
+
+// startThreeDS2 — первый ответ paymentorder.do для карты с 3-D Secure 2:
+// заказ ожидает аутентификации, клиент получает threeDSServerTransId.
+func (s *server) startThreeDS2(w http.ResponseWriter, orderID string, card cardData) {
+	transID, err := s.store.ids.Next()
+	if err != nil {
+		writeJSON(w, newGatewayError(code.ErrorSystem, err.Error()))
+		return
+	}
+
+	_, _, err = s.store.update(orderID, func(o *order) error {
+		if !payable(o) {
+			return errRejected
+		}
+		o.Status = types.OrderStatusPending
+		o.ThreeDSTransID = transID
+		setCard(o, card)
+		return nil
+	})
+	if err != nil {
+		writeJSON(w, errInvalidState)
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"errorCode":            "0",
+		"is3DSVer2":            true,
+		"threeDSServerTransId": transID,
+	})
+}
+
+// continueThreeDS2 — повторный paymentorder.do с threeDSServerTransId: песочница
+// всегда требует challenge и возвращает адрес своей страницы ACS.
+// packedCReq — непрозрачное значение, по которому страница ACS находит заказ (ID заказа).
+func (s *server) continueThreeDS2(w http.ResponseWriter, orderID, transID, finishURL string) {
+	_, _, err := s.store.update(orderID, func(o *order) error {
+		if o.Status != types.OrderStatusPending || o.ThreeDSTransID != transID {
+			return errRejected
+		}
+		o.ThreeDSFinishURL = finishURL
+		return nil
+	})
+	if err != nil {
+		writeJSON(w, errInvalidState)
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"errorCode":            "0",
+		"is3DSVer2":            true,
+		"threeDSServerTransId": transID,
+		"acsUrl":               s.publicURL + paymentRoot + acsPath,
+		"packedCReq":           orderID,
+	})
+}
+
+// completeChallenge — результат challenge 3DS2 на странице ACS. Заказ не меняет статус
+// до finishThreeDs.do; покупатель возвращается на threeDSVer2FinishUrl.
+func (s *server) completeChallenge(w http.ResponseWriter, r *http.Request, orderID string, confirmed bool) {
+	result := threeDSRejected
+	if confirmed {
+		result = threeDSConfirmed
+	}
+
+	o, _, err := s.store.update(orderID, func(o *order) error {
+		if o.Status != types.OrderStatusPending {
+			return errRejected
+		}
+		o.ThreeDSResult = result
+		return nil
+	})
+	if err != nil || o.ThreeDSFinishURL == "" {
+		http.Redirect(w, r, s.pageURL(orderID), http.StatusSeeOther)
+		return
+	}
+
+	target, err := url.Parse(o.ThreeDSFinishURL)
+	if err != nil {
+		http.Redirect(w, r, s.pageURL(orderID), http.StatusSeeOther)
+		return
+	}
+	query := target.Query()
+	query.Set("threeDSServerTransId", o.ThreeDSTransID)
+	target.RawQuery = query.Encode()
+	http.Redirect(w, r, target.String(), http.StatusSeeOther)
+}
+
+// finishThreeDS — finishThreeDs.do: завершение оплаты по результату challenge.
+func (s *server) finishThreeDS(w http.ResponseWriter, form url.Values) {
+	o, ok := s.store.findTrans(form.Get("tDsTransId"))
+	if !ok {
+		writeJSON(w, newGatewayError(code.ErrorOrderNotFound, ""))
+		return
+	}
+
+	var err error
+	switch o.ThreeDSResult {
+	case threeDSConfirmed:
+		o, err = s.approve(o.ID, cardData{})
+	case threeDSRejected:
+		o, err = s.fail(o.ID, code.DSecureFailed, cardData{})
+	default:
+		writeJSON(w, newGatewayError(code.ErrorSystem, "Аутентификация 3-D Secure не завершена"))
+		return
+	}
+	writePaymentResult(w, o, err)
+}
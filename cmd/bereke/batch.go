@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/bsagat/bereke-merchant-api/batch"
+)
+
+func runBatch(args []string) error {
+	fs, common := newFlagSet("batch")
+	file := fs.String("file", "", "файл с операциями (.csv или .jsonl)")
+	operation := fs.String("op", "", "операция по умолчанию: refund, reverse или cancel")
+	journalPath := fs.String("journal", "", "журнал прогресса (по умолчанию <file>.journal)")
+	reportPath := fs.String("report", "", "файл отчёта (.csv или .json; по умолчанию вывод в stdout)")
+	concurrency := fs.Int("concurrency", 4, "максимальное количество одновременных запросов")
+	rate := fs.Float64("rate", 5, "максимальное количество запросов в секунду (0 — без ограничения)")
+	retryUnknown := fs.Bool("retry-unknown", false, "повторить операции с неизвестным исходом (только после ручной проверки!)")
+	fs.Parse(args)
+
+	if err := required(fs, "file"); err != nil {
+		return err
+	}
+
+	items, err := batch.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+
+	if *journalPath == "" {
+		*journalPath = *file + ".journal"
+	}
+	journal, err := batch.OpenJournal(*journalPath)
+	if err != nil {
+		return err
+	}
+	defer journal.Close()
+
+	client, cfg, err := common.setup()
+	if err != nil {
+		return err
+	}
+	if err := common.confirm(cfg, fmt.Sprintf("пакетная операция над %d заказами из %s", len(items), *file)); err != nil {
+		return err
+	}
+
+	// Пакет выполняется без общего таймаута; прерывание по Ctrl+C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	processor := batch.New(client, batch.Config{
+		Operation:     batch.Operation(*operation),
+		Concurrency:   *concurrency,
+		RatePerSecond: *rate,
+		Journal:       journal,
+		RetryUnknown:  *retryUnknown,
+	})
+	report, err := processor.Run(ctx, items)
+	if err != nil {
+		return err
+	}
+
+	if err := writeReport(report, *reportPath, common.json); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Выполнено: %d, ошибок: %d, пропущено: %d, неизвестно: %d (журнал: %s)\n",
+		report.Done, report.Failed, report.Skipped, report.Unknown, *journalPath)
+
+	if report.Failed > 0 || report.Unknown > 0 {
+		return fmt.Errorf("не все операции выполнены (ошибок: %d, неизвестно: %d)", report.Failed, report.Unknown)
+	}
+	return nil
+}
+
+func writeReport(report batch.Report, path string, asJSON bool) error {
+	if path == "" {
+		if asJSON {
+			return report.WriteJSON(os.Stdout)
+		}
+		return report.WriteCSV(os.Stdout)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		err = report.WriteJSON(f)
+	} else {
+		err = report.WriteCSV(f)
+	}
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
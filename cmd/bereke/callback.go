@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/bereketest/callback"
+	money "github.com/bsagat/bereke-merchant-api/currency"
+)
+
+// paramFlags — повторяемый флаг --param имя=значение.
+type paramFlags url.Values
+
+func (p paramFlags) String() string {
+	return url.Values(p).Encode()
+}
+
+func (p paramFlags) Set(value string) error {
+	name, v, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("ожидается имя=значение: %s", value)
+	}
+	url.Values(p).Add(name, v)
+	return nil
+}
+
+// runCallback — отправка подписанного колбэка (как от шлюза) на локальный обработчик.
+// Учётные данные шлюза не нужны.
+func runCallback(args []string) error {
+	fs := flag.NewFlagSet("callback", flag.ExitOnError)
+	target := fs.String("url", "", "URL обработчика колбэков")
+	method := fs.String("method", http.MethodPost, "HTTP-метод: POST (параметры в теле) или GET (параметры в URL, как у шлюза)")
+	orderID := fs.String("order-id", "", "ID заказа в платёжном шлюзе (mdOrder)")
+	orderNumber := fs.String("order-number", "", "номер заказа в системе мерчанта")
+	operation := fs.String("operation", string(callback.Deposited), "операция: approved, deposited, reversed, refunded, declinedByTimeout")
+	status := fs.Int("status", 1, "результат операции: 1 — успех, 0 — ошибка")
+	amount := fs.Float64("amount", 0, "сумма операции в основных единицах валюты (0 — не передаётся)")
+	currency := fs.String("currency", money.KZT, "валюта суммы (KZT, USD, EUR, RUB или числовой код ISO 4217)")
+	hmacKey := fs.String("hmac-key", "", "секрет для симметричной контрольной суммы (HMAC-SHA256)")
+	rsaKey := fs.String("rsa-key", "", "PEM-файл закрытого ключа для асимметричной подписи (RSA SHA-256)")
+	rsaPassword := fs.String("rsa-password", "", "пароль закрытого ключа")
+	signAlias := fs.String("sign-alias", "", "значение sign_alias для асимметричной подписи")
+	timeout := fs.Duration("timeout", 10*time.Second, "таймаут запроса")
+	params := paramFlags{}
+	fs.Var(params, "param", "дополнительный параметр имя=значение (можно указать несколько раз)")
+	fs.Parse(args)
+
+	if err := required(fs, "url", "order-id"); err != nil {
+		return err
+	}
+	if *status != 0 && *status != 1 {
+		return errors.New("статус (--status) должен быть 0 или 1")
+	}
+	if *hmacKey != "" && *rsaKey != "" {
+		return errors.New("укажите только один способ подписи: --hmac-key или --rsa-key")
+	}
+	currencyCode, err := parseCurrency(*currency)
+	if err != nil {
+		return err
+	}
+
+	var signer callback.Signer
+	switch {
+	case *hmacKey != "":
+		signer = callback.HMAC(*hmacKey)
+	case *rsaKey != "":
+		if signer, err = callback.RSAFromFile(*rsaKey, *rsaPassword, *signAlias); err != nil {
+			return err
+		}
+	}
+
+	cb := callback.Callback{
+		OrderID:     *orderID,
+		OrderNumber: *orderNumber,
+		Operation:   callback.Operation(*operation),
+		Success:     *status == 1,
+		Amount:      money.ToMinorUnit(*amount, currencyCode),
+		Params:      url.Values(params),
+	}
+
+	var values url.Values
+	if signer != nil {
+		values, err = cb.Sign(signer)
+	} else if cb.Operation.Valid() {
+		values = cb.Values()
+	} else {
+		err = fmt.Errorf("неизвестная операция: %s", *operation)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	resp, err := callback.Send(ctx, http.DefaultClient, strings.ToUpper(*method), *target, values)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	fmt.Printf("Параметры: %s\n", values.Encode())
+	fmt.Printf("Ответ:     %s\n", resp.Status)
+	if len(body) > 0 {
+		fmt.Println(strings.TrimSpace(string(body)))
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("обработчик ответил %s", resp.Status)
+	}
+	return nil
+}
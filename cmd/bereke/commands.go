@@ -0,0 +1,368 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+	money "github.com/bsagat/bereke-merchant-api/currency"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+// commonFlags — флаги, общие для всех команд.
+type commonFlags struct {
+	config  string
+	mode    string
+	json    bool
+	yes     bool
+	timeout time.Duration
+}
+
+func newFlagSet(name string) (*flag.FlagSet, *commonFlags) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	common := &commonFlags{}
+	fs.StringVar(&common.config, "config", "", "путь к файлу конфигурации (JSON)")
+	fs.StringVar(&common.mode, "mode", "", "режим работы: TEST или PROD (по умолчанию из конфигурации, иначе TEST)")
+	fs.BoolVar(&common.json, "json", false, "вывод результата в формате JSON")
+	fs.BoolVar(&common.yes, "yes", false, "не запрашивать подтверждение операций в PROD")
+	fs.DurationVar(&common.timeout, "timeout", 30*time.Second, "таймаут запроса")
+	return fs, common
+}
+
+// setup — загрузка конфигурации и создание клиента с учётом флага --mode.
+func (c *commonFlags) setup() (bereke.API, config, error) {
+	cfg, err := loadConfig(c.config)
+	if err != nil {
+		return nil, config{}, err
+	}
+	if c.mode != "" {
+		cfg.Mode = c.mode
+	}
+
+	client, err := cfg.newClient()
+	if err != nil {
+		return nil, config{}, err
+	}
+	return client, cfg, nil
+}
+
+func (c *commonFlags) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.timeout)
+}
+
+// confirm — запрашивает подтверждение операции с движением денег в PROD-режиме.
+func (c *commonFlags) confirm(cfg config, action string) error {
+	if cfg.mode() != types.PROD || c.yes {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "PROD: %s. Продолжить? [y/N]: ", action)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return errors.New("операция не подтверждена")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes", "д", "да":
+		return nil
+	default:
+		return errors.New("операция отменена пользователем")
+	}
+}
+
+// parseCurrency — принимает буквенный ("KZT") или числовой ("398") код валюты.
+func parseCurrency(value string) (int, error) {
+	code := money.ToNumeric(strings.ToUpper(money.FromString(value)))
+	if code == 0 {
+		return 0, fmt.Errorf("неподдерживаемая валюта: %s", value)
+	}
+	return code, nil
+}
+
+func required(fs *flag.FlagSet, names ...string) error {
+	for _, name := range names {
+		if fs.Lookup(name).Value.String() == "" {
+			return fmt.Errorf("не указан обязательный флаг --%s", name)
+		}
+	}
+	return nil
+}
+
+// ------------------------------------------------------------
+// Команды
+// ------------------------------------------------------------
+
+func runStatus(args []string) error {
+	fs, common := newFlagSet("status")
+	orderID := fs.String("order-id", "", "ID заказа в платёжном шлюзе")
+	orderNumber := fs.String("order-number", "", "номер заказа в системе мерчанта")
+	fs.Parse(args)
+
+	if *orderID == "" && *orderNumber == "" {
+		return errors.New("укажите флаг --order-id или --order-number")
+	}
+
+	client, _, err := common.setup()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := common.context()
+	defer cancel()
+
+	resp, err := client.GetOrderStatus(ctx, core.OrderStatusRequest{OrderID: *orderID, OrderNumber: *orderNumber})
+	if err != nil {
+		return err
+	}
+	return output(common, resp, resp.Response)
+}
+
+func runRegister(args []string) error {
+	return register("register", args, false)
+}
+
+func runPreAuth(args []string) error {
+	return register("preauth", args, true)
+}
+
+func register(name string, args []string, preAuth bool) error {
+	fs, common := newFlagSet(name)
+	orderNumber := fs.String("order-number", "", "номер заказа в системе мерчанта")
+	amount := fs.Float64("amount", 0, "сумма заказа в основных единицах валюты")
+	currency := fs.String("currency", money.KZT, "валюта (KZT, USD, EUR, RUB или числовой код ISO 4217)")
+	returnURL := fs.String("return-url", "", "URL возврата после успешной оплаты")
+	failURL := fs.String("fail-url", "", "URL возврата при ошибке оплаты")
+	description := fs.String("description", "", "описание заказа")
+	language := fs.String("language", "", "язык платёжной страницы (ru, kk, en)")
+	fs.Parse(args)
+
+	if err := required(fs, "order-number", "return-url"); err != nil {
+		return err
+	}
+	if *amount <= 0 {
+		return errors.New("сумма (--amount) должна быть положительной")
+	}
+	currencyCode, err := parseCurrency(*currency)
+	if err != nil {
+		return err
+	}
+
+	client, _, err := common.setup()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := common.context()
+	defer cancel()
+
+	req := core.RegisterOrderRequest{
+		Order: core.Order{
+			OrderNumber: *orderNumber,
+			Amount:      *amount,
+			Currency:    currencyCode,
+			ReturnURL:   *returnURL,
+			FailURL:     *failURL,
+			Description: *description,
+			Language:    *language,
+		},
+	}
+
+	var resp core.RegisterOrderResponse
+	if preAuth {
+		resp, err = client.AuthOrder(ctx, req)
+	} else {
+		resp, err = client.RegisterOrder(ctx, req)
+	}
+	if err != nil {
+		return err
+	}
+	return output(common, resp, resp.Response)
+}
+
+func runDeposit(args []string) error {
+	fs, common := newFlagSet("deposit")
+	orderID := fs.String("order-id", "", "ID заказа в платёжном шлюзе")
+	amount := fs.Float64("amount", 0, "сумма списания (0 — вся авторизованная сумма)")
+	currency := fs.String("currency", money.KZT, "валюта (KZT, USD, EUR, RUB или числовой код ISO 4217)")
+	fs.Parse(args)
+
+	if err := required(fs, "order-id"); err != nil {
+		return err
+	}
+	currencyCode, err := parseCurrency(*currency)
+	if err != nil {
+		return err
+	}
+
+	client, cfg, err := common.setup()
+	if err != nil {
+		return err
+	}
+	if err := common.confirm(cfg, fmt.Sprintf("списание %s по заказу %s", amountText(*amount, *currency), *orderID)); err != nil {
+		return err
+	}
+	ctx, cancel := common.context()
+	defer cancel()
+
+	resp, err := client.DepositOrder(ctx, core.DepositOrderRequest{OrderID: *orderID, Amount: *amount, Currency: currencyCode})
+	if err != nil {
+		return err
+	}
+	return output(common, resp, resp)
+}
+
+func runReverse(args []string) error {
+	fs, common := newFlagSet("reverse")
+	orderID := fs.String("order-id", "", "ID заказа в платёжном шлюзе")
+	amount := fs.Float64("amount", 0, "сумма реверса (0 — вся сумма)")
+	currency := fs.String("currency", money.KZT, "валюта (KZT, USD, EUR, RUB или числовой код ISO 4217)")
+	fs.Parse(args)
+
+	if err := required(fs, "order-id"); err != nil {
+		return err
+	}
+	currencyCode, err := parseCurrency(*currency)
+	if err != nil {
+		return err
+	}
+
+	client, cfg, err := common.setup()
+	if err != nil {
+		return err
+	}
+	if err := common.confirm(cfg, fmt.Sprintf("реверс %s по заказу %s", amountText(*amount, *currency), *orderID)); err != nil {
+		return err
+	}
+	ctx, cancel := common.context()
+	defer cancel()
+
+	resp, err := client.ReversalOrder(ctx, core.ReversalOrderRequest{OrderID: *orderID, Amount: *amount, Currency: currencyCode})
+	if err != nil {
+		return err
+	}
+	return output(common, resp, resp)
+}
+
+func runRefund(args []string) error {
+	fs, common := newFlagSet("refund")
+	orderID := fs.String("order-id", "", "ID заказа в платёжном шлюзе")
+	amount := fs.Float64("amount", 0, "сумма возврата")
+	currency := fs.String("currency", money.KZT, "валюта (KZT, USD, EUR, RUB или числовой код ISO 4217)")
+	fs.Parse(args)
+
+	if err := required(fs, "order-id"); err != nil {
+		return err
+	}
+	if *amount <= 0 {
+		return errors.New("сумма (--amount) должна быть положительной")
+	}
+	currencyCode, err := parseCurrency(*currency)
+	if err != nil {
+		return err
+	}
+
+	client, cfg, err := common.setup()
+	if err != nil {
+		return err
+	}
+	if err := common.confirm(cfg, fmt.Sprintf("возврат %s по заказу %s", amountText(*amount, *currency), *orderID)); err != nil {
+		return err
+	}
+	ctx, cancel := common.context()
+	defer cancel()
+
+	resp, err := client.RefundOrder(ctx, core.RefundOrderRequest{OrderID: *orderID, Amount: *amount, Currency: currencyCode})
+	if err != nil {
+		return err
+	}
+	return output(common, resp, resp)
+}
+
+func runCancel(args []string) error {
+	fs, common := newFlagSet("cancel")
+	orderID := fs.String("order-id", "", "ID заказа в платёжном шлюзе")
+	fs.Parse(args)
+
+	if err := required(fs, "order-id"); err != nil {
+		return err
+	}
+
+	client, cfg, err := common.setup()
+	if err != nil {
+		return err
+	}
+	if err := common.confirm(cfg, fmt.Sprintf("отмена заказа %s", *orderID)); err != nil {
+		return err
+	}
+	ctx, cancel := common.context()
+	defer cancel()
+
+	resp, err := client.CancelOrderByID(ctx, *orderID)
+	if err != nil {
+		return err
+	}
+	return output(common, resp, resp)
+}
+
+func runPing(args []string) error {
+	fs, common := newFlagSet("ping")
+	fs.Parse(args)
+
+	client, _, err := common.setup()
+	if err != nil {
+		return err
+	}
+	if err := client.Ping(); err != nil {
+		return err
+	}
+
+	if common.json {
+		return printJSON(map[string]bool{"ok": true})
+	}
+	fmt.Println("OK")
+	return nil
+}
+
+func amountText(amount float64, currency string) string {
+	if amount == 0 {
+		return "всей суммы"
+	}
+	return fmt.Sprintf("%.2f %s", amount, strings.ToUpper(money.FromString(currency)))
+}
+
+func runHealth(args []string) error {
+	fs, common := newFlagSet("health")
+	fs.Parse(args)
+
+	client, _, err := common.setup()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := common.context()
+	defer cancel()
+
+	status, err := client.HealthCheck(ctx)
+	if common.json {
+		if perr := printJSON(status); perr != nil {
+			return perr
+		}
+		return err
+	}
+
+	fmt.Printf("Задержка:     %s\n", status.Latency.Round(time.Millisecond))
+	fmt.Printf("Авторизация:  %v\n", status.AuthValid)
+	if !status.CertificateExpiry.IsZero() {
+		fmt.Printf("Сертификат:   действителен до %s\n", status.CertificateExpiry.Format("2006-01-02"))
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println("OK")
+	return nil
+}
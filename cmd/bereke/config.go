@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+// config — учётные данные и режим работы CLI.
+// Источники по возрастанию приоритета: файл конфигурации, переменные окружения, флаги.
+type config struct {
+	Login        string `json:"login,omitempty"`
+	Password     string `json:"password,omitempty"`
+	Token        string `json:"token,omitempty"`
+	CertPath     string `json:"cert_path,omitempty"`
+	CertPassword string `json:"cert_password,omitempty"`
+	Mode         string `json:"mode,omitempty"`
+	URL          string `json:"url,omitempty"` // Адрес шлюза (например, локальной песочницы)
+}
+
+// defaultConfigPath — путь к файлу конфигурации по умолчанию (~/.config/bereke/config.json).
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "bereke", "config.json")
+}
+
+// loadConfig — читает файл конфигурации и применяет переменные окружения.
+// Отсутствие файла по пути по умолчанию не считается ошибкой.
+func loadConfig(path string) (config, error) {
+	var cfg config
+
+	explicit := path != ""
+	if !explicit {
+		path = os.Getenv("BEREKE_CONFIG")
+		explicit = path != ""
+	}
+	if !explicit {
+		path = defaultConfigPath()
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				return config{}, fmt.Errorf("некорректный файл конфигурации %s: %w", path, err)
+			}
+		case errors.Is(err, os.ErrNotExist) && !explicit:
+		default:
+			return config{}, err
+		}
+	}
+
+	override(&cfg.Login, "BEREKE_LOGIN")
+	override(&cfg.Password, "BEREKE_PASSWORD")
+	override(&cfg.Token, "BEREKE_TOKEN")
+	override(&cfg.CertPath, "BEREKE_CERT_PATH")
+	override(&cfg.CertPassword, "BEREKE_CERT_PASSWORD")
+	override(&cfg.Mode, "BEREKE_MODE")
+	override(&cfg.URL, "BEREKE_URL")
+
+	if cfg.Mode == "" {
+		cfg.Mode = string(types.TEST)
+	}
+	return cfg, nil
+}
+
+func override(field *string, env string) {
+	if val, ok := os.LookupEnv(env); ok && val != "" {
+		*field = val
+	}
+}
+
+func (c config) mode() types.Mode {
+	return types.Mode(strings.ToUpper(c.Mode))
+}
+
+// newClient — создаёт API клиент по доступным учётным данным:
+// сертификат, затем токен, затем логин/пароль.
+func (c config) newClient() (bereke.API, error) {
+	var opts []bereke.Option
+	if c.URL != "" {
+		opts = append(opts, bereke.WithGatewayURL(c.URL))
+	}
+
+	switch {
+	case c.CertPath != "":
+		return bereke.NewWithCertificate(c.CertPath, c.CertPassword, c.mode(), opts...)
+	case c.Token != "":
+		return bereke.NewWithToken(c.Token, c.mode(), opts...)
+	case c.Login != "" && c.Password != "":
+		return bereke.NewWithLogin(c.Login, c.Password, c.mode(), opts...)
+	default:
+		return nil, errors.New("не заданы учётные данные: укажите BEREKE_LOGIN/BEREKE_PASSWORD, BEREKE_TOKEN, BEREKE_CERT_PATH или файл конфигурации")
+	}
+}
@@ -0,0 +1,80 @@
+// Команда bereke — консольная утилита для операций с заказами Bereke Merchant API:
+// проверка статуса, регистрация, списание, реверс, возврат и отмена заказов,
+// а также отправка тестовых колбэков на обработчик мерчанта.
+//
+// Использование:
+//
+//	bereke <команда> [флаги]
+//
+// Учётные данные читаются из файла конфигурации (--config, BEREKE_CONFIG или
+// ~/.config/bereke/config.json) и переменных окружения BEREKE_LOGIN, BEREKE_PASSWORD,
+// BEREKE_TOKEN, BEREKE_CERT_PATH, BEREKE_CERT_PASSWORD, BEREKE_MODE, BEREKE_URL.
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+type command struct {
+	summary string
+	run     func(args []string) error
+}
+
+var commands = map[string]command{
+	"status":   {"статус заказа", runStatus},
+	"register": {"регистрация заказа (одностадийный платёж)", runRegister},
+	"preauth":  {"регистрация заказа с предавторизацией (двухстадийный платёж)", runPreAuth},
+	"deposit":  {"списание средств по предавторизованному заказу", runDeposit},
+	"reverse":  {"реверс (снятие блокировки) заказа", runReverse},
+	"refund":   {"возврат средств по заказу", runRefund},
+	"cancel":   {"отмена неоплаченного заказа", runCancel},
+	"batch":    {"пакетный возврат/реверс/отмена заказов из CSV или JSONL", runBatch},
+	"ping":     {"проверка доступности API", runPing},
+	"health":   {"проверка доступности шлюза, учётных данных и сертификата", runHealth},
+	"callback": {"отправка подписанного колбэка (как от шлюза) на локальный обработчик", runCallback},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	if name == "help" || name == "-h" || name == "--help" {
+		usage()
+		return
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "неизвестная команда: %s\n\n", name)
+		usage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "ошибка:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Использование: bereke <команда> [флаги]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Команды:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].summary)
+	}
+
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Подробнее о флагах команды: bereke <команда> -h")
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/bsagat/bereke-merchant-api/models/code"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+)
+
+// output — печатает результат команды и возвращает ошибку, если шлюз вернул ненулевой код.
+func output(common *commonFlags, result interface{}, resp core.Response) error {
+	var err error
+	if common.json {
+		err = printJSON(result)
+	} else {
+		printFields("", reflect.ValueOf(result))
+	}
+	if err != nil {
+		return err
+	}
+
+	if resp.ErrorCode != 0 {
+		msg := resp.ErrorMessage
+		if msg == "" {
+			msg = resp.ErrorCode.Describe(code.LangRu).Merchant
+		}
+		return fmt.Errorf("шлюз вернул ошибку %d: %s", resp.ErrorCode, msg)
+	}
+	return nil
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printFields — построчный вывод непустых полей структуры ("Поле: значение").
+// Встроенные структуры выводятся без префикса, вложенные — с префиксом "Родитель.".
+func printFields(prefix string, v reflect.Value) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		if !field.IsExported() || value.IsZero() {
+			continue
+		}
+
+		kind := value.Kind()
+		if kind == reflect.Pointer {
+			kind = value.Type().Elem().Kind()
+		}
+		if kind == reflect.Struct {
+			nested := prefix
+			if !field.Anonymous {
+				nested = prefix + field.Name + "."
+			}
+			printFields(nested, value)
+			continue
+		}
+
+		fmt.Printf("%s%s: %v\n", prefix, field.Name, value.Interface())
+	}
+}
@@ -0,0 +1,73 @@
+package bereke_merchant
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrUnexpectedResponse — шлюз вернул ответ, который не удалось разобрать как JSON
+// (например, HTML-страницу ошибки балансировщика). Конкретные данные — в *UnexpectedResponseError.
+var ErrUnexpectedResponse = errors.New("unexpected response from payment gateway")
+
+// ErrEmptyResponse — шлюз вернул пустое тело ответа там, где ожидались данные.
+// Возвращается внутри *UnexpectedResponseError: errors.Is срабатывает для обеих ошибок.
+var ErrEmptyResponse = errors.New("empty response body")
+
+// HTTPError — шлюз (или балансировщик перед ним) ответил HTTP-статусом вне диапазона 2xx.
+type HTTPError struct {
+	Endpoint   string // Endpoint шлюза (например, "register.do")
+	StatusCode int    // HTTP-статус ответа
+	Status     string // Строка статуса (например, "502 Bad Gateway")
+	Body       string // Начало тела ответа (до 256 символов)
+}
+
+func (e *HTTPError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("payment gateway returned HTTP %s: %s", e.Status, e.Endpoint)
+	}
+	return fmt.Sprintf("payment gateway returned HTTP %s: %s: %q", e.Status, e.Endpoint, e.Body)
+}
+
+// Temporary — true для статусов, при которых запрос имеет смысл повторить (429, 5xx).
+func (e *HTTPError) Temporary() bool {
+	return e.StatusCode == 429 || e.StatusCode >= 500
+}
+
+// bodySnippetLength — максимальная длина фрагмента тела ответа в ошибках.
+const bodySnippetLength = 256
+
+// UnexpectedResponseError — ответ шлюза, который не удалось декодировать.
+// errors.Is(err, ErrUnexpectedResponse) возвращает true.
+type UnexpectedResponseError struct {
+	Endpoint    string // Endpoint шлюза (например, "register.do")
+	StatusCode  int    // HTTP-статус ответа
+	ContentType string // Заголовок Content-Type ответа
+	Body        string // Начало тела ответа (до 256 символов)
+	Err         error  // Ошибка декодирования или *HTTPError, если статус вне 2xx
+}
+
+func (e *UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("%s: %s: HTTP %d (%s): %v: %q", ErrUnexpectedResponse, e.Endpoint, e.StatusCode, e.ContentType, e.Err, e.Body)
+}
+
+// Is — совместимость с errors.Is(err, ErrUnexpectedResponse).
+func (e *UnexpectedResponseError) Is(target error) bool {
+	return target == ErrUnexpectedResponse
+}
+
+// Unwrap — исходная ошибка декодирования.
+func (e *UnexpectedResponseError) Unwrap() error {
+	return e.Err
+}
+
+// bodySnippet — начало тела ответа для текста ошибки: пробелы схлопываются,
+// некорректные UTF-8 последовательности удаляются.
+func bodySnippet(body []byte) string {
+	s := strings.Join(strings.Fields(strings.ToValidUTF8(string(body), "")), " ")
+	if utf8.RuneCountInString(s) <= bodySnippetLength {
+		return s
+	}
+	return string([]rune(s)[:bodySnippetLength]) + "…"
+}
@@ -0,0 +1,142 @@
+package bereke_merchant
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bsagat/bereke-merchant-api/models/dto"
+)
+
+func TestDecodeResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+
+		unexpected bool   // errors.Is(err, ErrUnexpectedResponse)
+		httpStatus int    // *HTTPError.StatusCode; 0 — *HTTPError не ожидается
+		empty      bool   // errors.Is(err, ErrEmptyResponse)
+		snippet    string // начало Body в ошибке
+	}{
+		{
+			name:   "json",
+			status: http.StatusOK,
+			body:   `{"errorCode":"0","orderId":"id-1"}`,
+		},
+		{
+			name:        "html 502",
+			status:      http.StatusBadGateway,
+			contentType: "text/html",
+			body:        "<html>\n<head><title>502 Bad Gateway</title></head>\n<body>nginx</body>\n</html>",
+			unexpected:  true,
+			httpStatus:  http.StatusBadGateway,
+			snippet:     "<html> <head><title>502 Bad Gateway</title></head>",
+		},
+		{
+			name:        "json 500",
+			status:      http.StatusInternalServerError,
+			contentType: "application/json",
+			body:        `{"errorCode":"7","errorMessage":"Системная ошибка"}`,
+			httpStatus:  http.StatusInternalServerError,
+			snippet:     `{"errorCode":"7"`,
+		},
+		{
+			name:       "empty 503",
+			status:     http.StatusServiceUnavailable,
+			httpStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:        "html 200",
+			status:      http.StatusOK,
+			contentType: "text/html",
+			body:        "<html><body>Maintenance</body></html>",
+			unexpected:  true,
+			snippet:     "<html><body>Maintenance",
+		},
+		{
+			name:       "empty 200",
+			status:     http.StatusOK,
+			unexpected: true,
+			empty:      true,
+		},
+		{
+			name:       "truncated json",
+			status:     http.StatusOK,
+			body:       `{"errorCode":"0","orderId":"id`,
+			unexpected: true,
+			snippet:    `{"errorCode":"0"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.status,
+				Status:     http.StatusText(tt.status),
+				Header:     http.Header{"Content-Type": []string{tt.contentType}},
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+			var result dto.RegisterOrderResponse
+			err := decodeResponse(resp, "register.do", &result)
+
+			if tt.httpStatus == 0 && !tt.unexpected {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if result.OrderID != "id-1" {
+					t.Errorf("OrderID = %q, want id-1", result.OrderID)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if got := errors.Is(err, ErrUnexpectedResponse); got != tt.unexpected {
+				t.Errorf("errors.Is(ErrUnexpectedResponse) = %v, want %v (%v)", got, tt.unexpected, err)
+			}
+			if got := errors.Is(err, ErrEmptyResponse); got != tt.empty {
+				t.Errorf("errors.Is(ErrEmptyResponse) = %v, want %v", got, tt.empty)
+			}
+
+			var herr *HTTPError
+			switch {
+			case tt.httpStatus == 0 && errors.As(err, &herr):
+				t.Errorf("unexpected *HTTPError: %v", err)
+			case tt.httpStatus != 0 && (!errors.As(err, &herr) || herr.StatusCode != tt.httpStatus || herr.Endpoint != "register.do"):
+				t.Errorf("*HTTPError with status %d expected, got %v", tt.httpStatus, err)
+			}
+
+			body := ""
+			var uerr *UnexpectedResponseError
+			if errors.As(err, &uerr) {
+				if uerr.StatusCode != tt.status {
+					t.Errorf("StatusCode = %d, want %d", uerr.StatusCode, tt.status)
+				}
+				if uerr.ContentType != tt.contentType {
+					t.Errorf("ContentType = %q, want %q", uerr.ContentType, tt.contentType)
+				}
+				body = uerr.Body
+			} else if herr != nil {
+				body = herr.Body
+			}
+			if !strings.HasPrefix(body, tt.snippet) {
+				t.Errorf("Body = %q, want prefix %q", body, tt.snippet)
+			}
+		})
+	}
+}
+
+func TestBodySnippet(t *testing.T) {
+	long := strings.Repeat("ж", bodySnippetLength+10)
+	if got := bodySnippet([]byte(long)); got != strings.Repeat("ж", bodySnippetLength)+"…" {
+		t.Errorf("long body snippet = %q", got)
+	}
+	if got := bodySnippet([]byte("a \n\t b\xff")); got != "a b" {
+		t.Errorf("snippet = %q, want %q", got, "a b")
+	}
+}
@@ -0,0 +1,97 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Subscriber — получатель событий.
+type Subscriber interface {
+	// Handle — обработка события. Ошибка возвращается из Publish.
+	Handle(ctx context.Context, event Event) error
+}
+
+// SubscriberFunc — адаптер функции к интерфейсу Subscriber.
+type SubscriberFunc func(ctx context.Context, event Event) error
+
+// Handle — вызов функции.
+func (f SubscriberFunc) Handle(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// Publisher — получатель потока событий (шина, outbox-таблица, брокер сообщений).
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
+
+// Bus — шина событий в памяти процесса. События доставляются подписчикам синхронно,
+// в порядке публикации и подписки.
+type Bus struct {
+	mu     sync.RWMutex
+	nextID int
+	subs   []subscription
+}
+
+type subscription struct {
+	id         int
+	subscriber Subscriber
+	types      map[Type]bool // nil — все типы событий
+}
+
+var _ Publisher = (*Bus)(nil)
+
+// NewBus — создание шины событий.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe — подписка на события указанных типов (без типов — на все события).
+// Возвращает функцию отмены подписки.
+func (b *Bus) Subscribe(s Subscriber, types ...Type) (unsubscribe func()) {
+	sub := subscription{subscriber: s}
+	if len(types) > 0 {
+		sub.types = make(map[Type]bool, len(types))
+		for _, t := range types {
+			sub.types[t] = true
+		}
+	}
+
+	b.mu.Lock()
+	b.nextID++
+	sub.id = b.nextID
+	b.subs = append(b.subs, sub)
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, existing := range b.subs {
+			if existing.id == sub.id {
+				b.subs = append(b.subs[:i:i], b.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish — доставка событий всем подходящим подписчикам.
+// Ошибка одного подписчика не прерывает доставку остальным; ошибки объединяются.
+func (b *Bus) Publish(ctx context.Context, events ...Event) error {
+	b.mu.RLock()
+	subs := b.subs
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, event := range events {
+		for _, sub := range subs {
+			if sub.types != nil && !sub.types[event.Type] {
+				continue
+			}
+			if err := sub.subscriber.Handle(ctx, event); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,154 @@
+// Package events — единый поток событий жизненного цикла заказа.
+//
+// События выводятся из разницы последовательных снимков core.OrderStatusResponse,
+// поэтому не зависят от источника статуса: колбэка, периодического опроса
+// или ответа на собственный вызов API. Tracker хранит последний снимок каждого заказа
+// и публикует новые события в Bus, на который подписываются обработчики.
+//
+//	bus := events.NewBus()
+//	bus.Subscribe(events.SubscriberFunc(func(ctx context.Context, e events.Event) error {
+//		return ledger.Record(ctx, e.OrderNumber, e.Type, e.Amount)
+//	}), events.OrderDeposited, events.OrderRefunded)
+//
+//	tracker := events.NewTracker(bus)
+//	status, err := api.GetOrderStatus(ctx, core.OrderStatusRequest{OrderID: orderID})
+//	err = tracker.Observe(ctx, status)
+package events
+
+import (
+	"time"
+
+	money "github.com/bsagat/bereke-merchant-api/currency"
+	"github.com/bsagat/bereke-merchant-api/models/code"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+// Type — тип события заказа.
+type Type string
+
+const (
+	OrderRegistered Type = "order.registered" // заказ впервые наблюдается (зарегистрирован в шлюзе)
+	OrderAuthorized Type = "order.authorized" // средства авторизованы (заблокированы)
+	OrderDeposited  Type = "order.deposited"  // средства списаны (полностью или частично)
+	OrderRefunded   Type = "order.refunded"   // выполнен возврат (полный или частичный)
+	OrderReversed   Type = "order.reversed"   // авторизация отменена
+	OrderDeclined   Type = "order.declined"   // оплата отклонена
+)
+
+// Event — событие жизненного цикла заказа.
+type Event struct {
+	Type        Type
+	OrderID     string // ID заказа в шлюзе
+	OrderNumber string // Номер заказа в системе мерчанта
+
+	// Сумма события в основных единицах валюты: для списаний и возвратов — сумма этой операции
+	// (разница с предыдущим снимком), для остальных событий — сумма заказа
+	Amount   float64
+	Currency int // Код валюты (ISO 4217)
+
+	ActionCode code.ActionCode // Код ответа процессинга на момент события
+	Time       time.Time       // Время операции по данным шлюза (или время наблюдения, если шлюз его не вернул)
+
+	Status core.OrderStatusResponse // Снимок статуса, из которого выведено событие
+}
+
+// Diff — события между предыдущим (prev) и текущим (next) снимками статуса одного заказа.
+// prev = nil означает, что заказ наблюдается впервые: тогда первым идёт OrderRegistered,
+// а затем события, которые привели заказ в текущее состояние.
+// Одностадийная оплата даёт пару событий OrderAuthorized и OrderDeposited.
+func Diff(prev *core.OrderStatusResponse, next core.OrderStatusResponse) []Event {
+	var (
+		events []Event
+		before core.OrderStatusResponse
+	)
+	observed := time.Now().UTC()
+
+	newEvent := func(t Type, minorAmount int64, at time.Time) Event {
+		amount := next.Amount
+		if minorAmount > 0 {
+			amount = money.ConvertFromMinorUnits(int(minorAmount), next.Currency)
+		}
+		if at.IsZero() {
+			at = observed
+		}
+		return Event{
+			Type:        t,
+			OrderID:     next.OrderID,
+			OrderNumber: next.OrderNumber,
+			Amount:      amount,
+			Currency:    next.Currency,
+			ActionCode:  next.ActionCode,
+			Time:        at,
+			Status:      next,
+		}
+	}
+
+	if prev == nil {
+		events = append(events, newEvent(OrderRegistered, 0, next.CreatedAt()))
+	} else {
+		before = *prev
+	}
+
+	if authorized(next) && !authorized(before) {
+		events = append(events, newEvent(OrderAuthorized, next.PaymentAmountInfo.ApprovedAmount, next.AuthorizedAt()))
+	}
+
+	if delta := deposited(next) - deposited(before); delta > 0 {
+		events = append(events, newEvent(OrderDeposited, delta, next.DepositedAt()))
+	}
+
+	if delta := refunded(next) - refunded(before); delta > 0 {
+		events = append(events, newEvent(OrderRefunded, delta, next.RefundedAt()))
+	}
+
+	if next.OrderStatus == types.OrderStatusCancelled && before.OrderStatus != types.OrderStatusCancelled {
+		events = append(events, newEvent(OrderReversed, 0, next.ReversedAt()))
+	}
+
+	if next.OrderStatus == types.OrderStatusDeclined && before.OrderStatus != types.OrderStatusDeclined {
+		events = append(events, newEvent(OrderDeclined, 0, time.Time{}))
+	}
+
+	return events
+}
+
+// authorized — средства по заказу были авторизованы.
+func authorized(s core.OrderStatusResponse) bool {
+	if s.PaymentAmountInfo.ApprovedAmount > 0 {
+		return true
+	}
+	switch s.OrderStatus {
+	case types.OrderStatusAuthorized, types.OrderStatusCompleted, types.OrderStatusPartial,
+		types.OrderStatusCancelled, types.OrderStatusRefunded:
+		return true
+	default:
+		return false
+	}
+}
+
+// deposited — списанная сумма в минорных единицах. Если шлюз не вернул paymentAmountInfo,
+// списанной считается вся сумма завершённого заказа.
+func deposited(s core.OrderStatusResponse) int64 {
+	if s.PaymentAmountInfo.DepositedAmount > 0 {
+		return s.PaymentAmountInfo.DepositedAmount
+	}
+	switch s.OrderStatus {
+	case types.OrderStatusCompleted, types.OrderStatusRefunded:
+		return int64(money.ToMinorUnit(s.Amount, s.Currency))
+	default:
+		return 0
+	}
+}
+
+// refunded — возвращённая сумма в минорных единицах. Если шлюз не вернул paymentAmountInfo,
+// возвращённой считается вся сумма заказа в статусе «возврат».
+func refunded(s core.OrderStatusResponse) int64 {
+	if s.PaymentAmountInfo.RefundedAmount > 0 {
+		return s.PaymentAmountInfo.RefundedAmount
+	}
+	if s.OrderStatus == types.OrderStatusRefunded {
+		return int64(money.ToMinorUnit(s.Amount, s.Currency))
+	}
+	return 0
+}
@@ -0,0 +1,151 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+// Tracker — источник событий из снимков статуса заказов.
+// Хранит последний снимок каждого заказа в памяти процесса.
+type Tracker struct {
+	publisher Publisher
+
+	mu        sync.Mutex
+	snapshots map[string]core.OrderStatusResponse
+	locks     map[string]*keyLock
+}
+
+type keyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// NewTracker — создание трекера, публикующего события в publisher.
+func NewTracker(publisher Publisher) *Tracker {
+	return &Tracker{
+		publisher: publisher,
+		snapshots: map[string]core.OrderStatusResponse{},
+		locks:     map[string]*keyLock{},
+	}
+}
+
+// Observe — учёт нового снимка статуса заказа (из колбэка, опроса или ответа API):
+// публикует события, которых не было в предыдущем снимке.
+//
+// Снимок сохраняется только после успешной публикации, поэтому при ошибке подписчика
+// события будут опубликованы повторно при следующем вызове (доставка «как минимум один раз»).
+// Ответы с ошибкой шлюза и снимки, отстающие от сохранённого (меньше списанная или возвращённая
+// сумма, более ранний статус — например, ответ опроса, пришедший после колбэка), игнорируются.
+func (t *Tracker) Observe(ctx context.Context, status core.OrderStatusResponse) error {
+	if !status.ErrorCode.IsSuccess() {
+		return nil
+	}
+	key := snapshotKey(status)
+	if key == "" {
+		return errors.New("order status snapshot has neither order id nor order number")
+	}
+
+	// Снимки одного заказа публикуются последовательно, разных заказов — независимо
+	unlock := t.lock(key)
+	defer unlock()
+
+	t.mu.Lock()
+	last, ok := t.snapshots[key]
+	t.mu.Unlock()
+
+	var prev *core.OrderStatusResponse
+	if ok {
+		if stale(last, status) {
+			return nil
+		}
+		prev = &last
+	}
+
+	if events := Diff(prev, status); len(events) > 0 {
+		if err := t.publisher.Publish(ctx, events...); err != nil {
+			return err
+		}
+	}
+
+	t.mu.Lock()
+	t.snapshots[key] = status
+	t.mu.Unlock()
+	return nil
+}
+
+// Forget — удаление снимка заказа (например, после перевода заказа в архив).
+// key — ID заказа в шлюзе или номер заказа, если шлюз не вернул ID в статусе.
+func (t *Tracker) Forget(key string) {
+	unlock := t.lock(key)
+	defer unlock()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.snapshots, key)
+}
+
+// snapshotKey — ключ снимка: ID заказа в шлюзе, а если getOrderStatusExtended.do
+// его не вернул — номер заказа мерчанта.
+func snapshotKey(status core.OrderStatusResponse) string {
+	if status.OrderID != "" {
+		return status.OrderID
+	}
+	return status.OrderNumber
+}
+
+// stale — снимок next отстаёт от сохранённого prev: списанная или возвращённая сумма меньше
+// либо статус находится на более ранней стадии жизненного цикла заказа.
+func stale(prev, next core.OrderStatusResponse) bool {
+	return deposited(next) < deposited(prev) ||
+		refunded(next) < refunded(prev) ||
+		stage(next.OrderStatus) < stage(prev.OrderStatus)
+}
+
+// stage — стадия жизненного цикла заказа: статус не может вернуться на более раннюю стадию.
+func stage(status types.OrderStatus) int {
+	switch status {
+	case types.OrderStatusRegistered, types.OrderStatusWaiting:
+		return 0
+	case types.OrderStatusPending:
+		return 1
+	case types.OrderStatusAuthorized:
+		return 2
+	case types.OrderStatusPartial:
+		return 3
+	case types.OrderStatusCompleted:
+		return 4
+	case types.OrderStatusCancelled, types.OrderStatusRefunded, types.OrderStatusDeclined:
+		return 5
+	default:
+		return 0
+	}
+}
+
+// lock — блокировка по ключу снимка: снимки одного заказа обрабатываются последовательно,
+// разных заказов — независимо, в том числе пока подписчики обрабатывают события.
+func (t *Tracker) lock(key string) func() {
+	t.mu.Lock()
+	l, ok := t.locks[key]
+	if !ok {
+		l = &keyLock{}
+		t.locks[key] = l
+	}
+	l.refs++
+	t.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+
+		t.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(t.locks, key)
+		}
+		t.mu.Unlock()
+	}
+}
@@ -0,0 +1,92 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+type publisherFunc func(ctx context.Context, events ...Event) error
+
+func (f publisherFunc) Publish(ctx context.Context, events ...Event) error {
+	return f(ctx, events...)
+}
+
+func TestObserveSkipsStaleSnapshots(t *testing.T) {
+	var got []Type
+	tracker := NewTracker(publisherFunc(func(_ context.Context, events ...Event) error {
+		for _, e := range events {
+			got = append(got, e.Type)
+		}
+		return nil
+	}))
+
+	order := func(status types.OrderStatus, deposited, refunded int64) core.OrderStatusResponse {
+		return core.OrderStatusResponse{
+			OrderID:     "order-1",
+			Amount:      1000,
+			Currency:    398,
+			OrderStatus: status,
+			PaymentAmountInfo: core.PaymentAmountInfo{
+				ApprovedAmount:  100000,
+				DepositedAmount: deposited,
+				RefundedAmount:  refunded,
+			},
+		}
+	}
+
+	snapshots := []core.OrderStatusResponse{
+		order(types.OrderStatusCompleted, 100000, 0),
+		order(types.OrderStatusCompleted, 100000, 30000),
+		order(types.OrderStatusCompleted, 100000, 0), // опрос отстал от колбэка возврата
+		order(types.OrderStatusAuthorized, 0, 0),     // колбэк approved после deposited
+		order(types.OrderStatusRefunded, 100000, 100000),
+		order(types.OrderStatusCompleted, 100000, 30000),
+	}
+	for _, s := range snapshots {
+		if err := tracker.Observe(context.Background(), s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []Type{OrderRegistered, OrderAuthorized, OrderDeposited, OrderRefunded, OrderRefunded}
+	if len(got) != len(want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("events = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestObserveDoesNotBlockOtherOrders(t *testing.T) {
+	release := make(chan struct{})
+	tracker := NewTracker(publisherFunc(func(_ context.Context, events ...Event) error {
+		if events[0].OrderID == "slow" {
+			<-release
+		}
+		return nil
+	}))
+	defer close(release)
+
+	go tracker.Observe(context.Background(), core.OrderStatusResponse{OrderID: "slow"})
+
+	done := make(chan error, 1)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		done <- tracker.Observe(context.Background(), core.OrderStatusResponse{OrderID: "fast"})
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Observe of another order is blocked by a slow subscriber")
+	}
+}
@@ -0,0 +1,155 @@
+package bereke_merchant
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/internal/sign"
+	"github.com/bsagat/bereke-merchant-api/models/code"
+	"github.com/bsagat/bereke-merchant-api/models/dto"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+// healthCheckOrderID — ID заведомо несуществующего заказа для проверки авторизации.
+const healthCheckOrderID = "00000000-0000-0000-0000-000000000000"
+
+// ErrAuthFailed — шлюз отклонил учётные данные мерчанта.
+var ErrAuthFailed = errors.New("payment gateway rejected merchant credentials")
+
+// HealthStatus — результат проверки работоспособности клиента.
+type HealthStatus struct {
+	Healthy      bool           // Шлюз доступен, учётные данные и сертификат действительны
+	Latency      time.Duration  // Время ответа шлюза
+	AuthValid    bool           // Шлюз принял учётные данные
+	ErrorCode    code.ErrorCode // Код ошибки шлюза на проверочный запрос
+	ErrorMessage string         // Сообщение шлюза
+
+	// Срок действия сертификата (только для AuthCertificate, если PEM-файл содержит сертификат)
+	CertificateExpiry time.Time
+}
+
+// HealthCheck — проверка доступности шлюза и действительности учётных данных.
+//
+// Выполняет авторизованный запрос статуса несуществующего заказа (getOrderStatusExtended.do):
+// ответ code.ErrorOrderNotFound означает, что шлюз доступен и принял учётные данные,
+// code.ErrorInvalidParameter («Доступ запрещён») — ошибку авторизации.
+// Для AuthCertificate дополнительно проверяется ключ и срок действия сертификата.
+//
+// Ошибка возвращается, если клиент неработоспособен; HealthStatus заполняется в любом случае.
+func (a *api) HealthCheck(ctx context.Context) (HealthStatus, error) {
+	var status HealthStatus
+
+	if a.authType == types.AuthCertificate {
+		expiry, err := a.checkCertificate()
+		status.CertificateExpiry = expiry
+		if err != nil {
+			return status, err
+		}
+	}
+
+	params := url.Values{}
+	params.Set("orderId", healthCheckOrderID)
+
+	var response dto.OrderStatusResponse
+	start := time.Now()
+	err := a.sendRequest(ctx, GET, "getOrderStatusExtended.do", params, &response)
+	status.Latency = time.Since(start)
+	if err != nil {
+		return status, fmt.Errorf("payment gateway is unreachable: %w", err)
+	}
+
+	status.ErrorCode = response.GatewayErrorCode()
+	status.ErrorMessage = response.ErrorMessage
+
+	switch status.ErrorCode {
+	case code.ErrorOrderNotFound, code.ErrorNone:
+		status.AuthValid = true
+	case code.ErrorInvalidParameter:
+		return status, fmt.Errorf("%w: %s", ErrAuthFailed, status.ErrorMessage)
+	default:
+		return status, fmt.Errorf("unexpected health check response: errorCode=%d %s", status.ErrorCode, status.ErrorMessage)
+	}
+
+	status.Healthy = true
+	return status, nil
+}
+
+// checkCertificate — проверка ключа подписи и срока действия сертификата из certPath.
+// Возвращает нулевое время, если PEM-файл не содержит сертификата.
+func (a *api) checkCertificate() (time.Time, error) {
+	if _, err := sign.LoadEncryptedPrivateKey(a.certPath, []byte(a.certPassphrase)); err != nil {
+		return time.Time{}, fmt.Errorf("invalid signing key: %w", err)
+	}
+
+	pemBytes, err := os.ReadFile(a.certPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for block, rest := pem.Decode(pemBytes); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid certificate: %w", err)
+		}
+		if time.Now().After(cert.NotAfter) {
+			return cert.NotAfter, fmt.Errorf("certificate expired at %s", cert.NotAfter.Format(time.RFC3339))
+		}
+		return cert.NotAfter, nil
+	}
+	return time.Time{}, nil
+}
+
+// HealthHandler — HTTP-обработчик для readiness-проб (например, Kubernetes).
+// Отвечает 200 OK, если HealthCheck прошёл успешно, иначе 503 Service Unavailable.
+// Тело ответа — JSON с результатом проверки. timeout <= 0 — без собственного таймаута.
+func HealthHandler(api API, timeout time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		status, err := api.HealthCheck(ctx)
+
+		body := struct {
+			Healthy           bool           `json:"healthy"`
+			LatencyMs         int64          `json:"latency_ms"`
+			AuthValid         bool           `json:"auth_valid"`
+			ErrorCode         code.ErrorCode `json:"error_code"`
+			CertificateExpiry *time.Time     `json:"certificate_expiry,omitempty"`
+			Error             string         `json:"error,omitempty"`
+		}{
+			Healthy:   status.Healthy,
+			LatencyMs: status.Latency.Milliseconds(),
+			AuthValid: status.AuthValid,
+			ErrorCode: status.ErrorCode,
+		}
+		if !status.CertificateExpiry.IsZero() {
+			body.CertificateExpiry = &status.CertificateExpiry
+		}
+		if err != nil {
+			body.Error = err.Error()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if status.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	})
+}
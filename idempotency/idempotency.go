@@ -0,0 +1,195 @@
+// Package idempotency — идемпотентная регистрация заказов.
+//
+// Если сервис падает после RegisterOrder, но до сохранения OrderID, повторная
+// регистрация с тем же номером заказа завершается ошибкой-дублем (code.ErrorCode.IsDuplicateOrder)
+// и ссылка на платёжную форму теряется. Registrar запоминает результат регистрации
+// в OrderStore, а при дубле восстанавливает существующий заказ через GetOrderStatus.
+//
+//	store, err := idempotency.OpenFileStore("orders.jsonl")
+//	registrar := idempotency.New(api, store, idempotency.Config{
+//		FormURL: func(orderID string) string {
+//			return "https://securepayments.berekebank.kz/payment/merchants/shop/payment_ru.html?mdOrder=" + orderID
+//		},
+//	})
+//	resp, err := registrar.RegisterOrder(ctx, req)
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+	money "github.com/bsagat/bereke-merchant-api/currency"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+)
+
+var (
+	// ErrOrderMismatch — заказ с таким номером уже зарегистрирован с другой суммой или валютой.
+	ErrOrderMismatch = errors.New("order with this number is already registered with different amount or currency")
+
+	// ErrFormURLUnknown — существующий заказ восстановлен, но URL платёжной формы неизвестен
+	// (шлюз не возвращает его в статусе заказа, а Config.FormURL не задан).
+	// Ответ при этом содержит OrderID.
+	ErrFormURLUnknown = errors.New("order recovered but payment form URL is unknown")
+)
+
+// Config — параметры идемпотентной регистрации.
+type Config struct {
+	// FormURL — построение URL платёжной формы по ID заказа.
+	// Используется при восстановлении заказа после ошибки-дубля.
+	FormURL func(orderID string) string
+}
+
+// Registrar — идемпотентная обёртка над RegisterOrder/AuthOrder.
+type Registrar struct {
+	api   bereke.API
+	store OrderStore
+	cfg   Config
+
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// New — создание обёртки поверх API клиента и хранилища.
+func New(api bereke.API, store OrderStore, cfg Config) *Registrar {
+	return &Registrar{api: api, store: store, cfg: cfg, locks: map[string]*keyLock{}}
+}
+
+// RegisterOrder — идемпотентная регистрация заказа (одностадийный платёж).
+//
+// Повторный вызов с тем же OrderNumber возвращает сохранённые OrderID и FormURL
+// без запроса к шлюзу. Если шлюз отвечает ошибкой-дублем, существующий заказ
+// восстанавливается через GetOrderStatus по OrderNumber.
+func (r *Registrar) RegisterOrder(ctx context.Context, req core.RegisterOrderRequest) (core.RegisterOrderResponse, error) {
+	return r.register(ctx, req, r.api.RegisterOrder)
+}
+
+// AuthOrder — идемпотентная регистрация заказа с предавторизацией (двухстадийный платёж).
+func (r *Registrar) AuthOrder(ctx context.Context, req core.RegisterOrderRequest) (core.RegisterOrderResponse, error) {
+	return r.register(ctx, req, r.api.AuthOrder)
+}
+
+func (r *Registrar) register(
+	ctx context.Context,
+	req core.RegisterOrderRequest,
+	send func(context.Context, core.RegisterOrderRequest) (core.RegisterOrderResponse, error),
+) (core.RegisterOrderResponse, error) {
+	if req.OrderNumber == "" {
+		return core.RegisterOrderResponse{}, errors.New("order number is required for idempotent registration")
+	}
+
+	unlock := r.lock(req.OrderNumber)
+	defer unlock()
+
+	rec, ok, err := r.store.Get(ctx, req.OrderNumber)
+	if err != nil {
+		return core.RegisterOrderResponse{}, fmt.Errorf("order store: %w", err)
+	}
+	if ok {
+		if !sameAmount(rec.Amount, rec.Currency, req.Amount, req.Currency) {
+			return core.RegisterOrderResponse{}, ErrOrderMismatch
+		}
+		return core.RegisterOrderResponse{OrderID: rec.OrderID, FormURL: rec.FormURL}, nil
+	}
+
+	resp, err := send(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	switch {
+	case resp.ErrorCode.IsSuccess():
+		return resp, r.save(ctx, req, resp.OrderID, resp.FormURL)
+	case resp.ErrorCode.IsDuplicateOrder():
+		return r.recoverExisting(ctx, req, resp)
+	default:
+		return resp, nil
+	}
+}
+
+// recoverExisting — восстановление уже зарегистрированного заказа по номеру.
+func (r *Registrar) recoverExisting(ctx context.Context, req core.RegisterOrderRequest, duplicate core.RegisterOrderResponse) (core.RegisterOrderResponse, error) {
+	status, err := r.api.GetOrderStatus(ctx, core.OrderStatusRequest{OrderNumber: req.OrderNumber})
+	if err != nil {
+		return duplicate, fmt.Errorf("recover duplicate order: %w", err)
+	}
+	if !status.ErrorCode.IsSuccess() || status.OrderID == "" {
+		return duplicate, fmt.Errorf("recover duplicate order: errorCode=%d %s", status.ErrorCode, status.ErrorMessage)
+	}
+	if !sameAmount(status.Amount, status.Currency, req.Amount, req.Currency) {
+		return duplicate, ErrOrderMismatch
+	}
+
+	resp := core.RegisterOrderResponse{OrderID: status.OrderID}
+	if r.cfg.FormURL != nil {
+		resp.FormURL = r.cfg.FormURL(status.OrderID)
+	}
+	if err := r.save(ctx, req, resp.OrderID, resp.FormURL); err != nil {
+		return resp, err
+	}
+	if resp.FormURL == "" {
+		return resp, ErrFormURLUnknown
+	}
+	return resp, nil
+}
+
+func (r *Registrar) save(ctx context.Context, req core.RegisterOrderRequest, orderID, formURL string) error {
+	err := r.store.Put(ctx, Record{
+		OrderNumber: req.OrderNumber,
+		OrderID:     orderID,
+		FormURL:     formURL,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		CreatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("order registered but not saved to store: %w", err)
+	}
+	return nil
+}
+
+// lock — блокировка по номеру заказа: параллельные вызовы с одним номером
+// выполняются последовательно, с разными — независимо.
+func (r *Registrar) lock(orderNumber string) func() {
+	r.mu.Lock()
+	l, ok := r.locks[orderNumber]
+	if !ok {
+		l = &keyLock{}
+		r.locks[orderNumber] = l
+	}
+	l.refs++
+	r.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+
+		r.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(r.locks, orderNumber)
+		}
+		r.mu.Unlock()
+	}
+}
+
+// sameAmount — сравнение сумм в минимальных единицах валюты.
+// Нулевая валюта означает валюту по умолчанию и совпадает с любой.
+func sameAmount(amountA float64, currencyA int, amountB float64, currencyB int) bool {
+	if currencyA != 0 && currencyB != 0 && currencyA != currencyB {
+		return false
+	}
+	currency := currencyA
+	if currency == 0 {
+		currency = currencyB
+	}
+	return money.ToMinorUnit(amountA, currency) == money.ToMinorUnit(amountB, currency)
+}
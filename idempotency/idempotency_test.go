@@ -0,0 +1,205 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+	"github.com/bsagat/bereke-merchant-api/models/code"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+)
+
+// fakeAPI — шлюз, который регистрирует заказ один раз, а на повтор отвечает дублем.
+type fakeAPI struct {
+	bereke.API
+
+	duplicate code.ErrorCode
+	status    core.OrderStatusResponse
+	delay     time.Duration
+
+	registers, statuses atomic.Int32
+	inFlight, maxFlight atomic.Int32
+
+	mu         sync.Mutex
+	registered map[string]bool
+}
+
+func (f *fakeAPI) RegisterOrder(_ context.Context, req core.RegisterOrderRequest) (core.RegisterOrderResponse, error) {
+	f.registers.Add(1)
+	if n := f.inFlight.Add(1); n > f.maxFlight.Load() {
+		f.maxFlight.Store(n)
+	}
+	defer f.inFlight.Add(-1)
+	time.Sleep(f.delay)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.registered[req.OrderNumber] {
+		return core.RegisterOrderResponse{Response: core.Response{ErrorCode: f.duplicate, ErrorMessage: "Заказ с таким номером уже обработан"}}, nil
+	}
+	if f.registered == nil {
+		f.registered = map[string]bool{}
+	}
+	f.registered[req.OrderNumber] = true
+	return core.RegisterOrderResponse{OrderID: "id-" + req.OrderNumber, FormURL: "https://pay.example.com/form?mdOrder=id-" + req.OrderNumber}, nil
+}
+
+func (f *fakeAPI) GetOrderStatus(_ context.Context, req core.OrderStatusRequest) (core.OrderStatusResponse, error) {
+	f.statuses.Add(1)
+	if req.OrderNumber == "" {
+		return core.OrderStatusResponse{}, errors.New("status requested without order number")
+	}
+	return f.status, nil
+}
+
+func request(amount float64, currency int) core.RegisterOrderRequest {
+	return core.RegisterOrderRequest{Order: core.Order{OrderNumber: "A-1", Amount: amount, Currency: currency, ReturnURL: "https://shop.example.com/ok"}}
+}
+
+func formURL(orderID string) string {
+	return "https://pay.example.com/form?mdOrder=" + orderID
+}
+
+func TestRecoverDuplicate(t *testing.T) {
+	for _, duplicate := range []code.ErrorCode{code.ErrorInvalidOrderNumber, code.ErrorDuplicateOrder} {
+		t.Run(duplicate.String(), func(t *testing.T) {
+			// Заказ уже зарегистрирован до падения сервиса, но не сохранён в хранилище
+			api := &fakeAPI{
+				duplicate:  duplicate,
+				registered: map[string]bool{"A-1": true},
+				status:     core.OrderStatusResponse{OrderID: "id-A-1", OrderNumber: "A-1", Amount: 1500, Currency: 398},
+			}
+			store := NewMemoryStore()
+			registrar := New(api, store, Config{FormURL: formURL})
+
+			resp, err := registrar.RegisterOrder(context.Background(), request(1500, 398))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.OrderID != "id-A-1" || resp.FormURL != formURL("id-A-1") {
+				t.Errorf("response = %+v, want recovered OrderID and FormURL", resp)
+			}
+
+			rec, ok, _ := store.Get(context.Background(), "A-1")
+			if !ok || rec.OrderID != "id-A-1" {
+				t.Errorf("recovered order was not saved: %+v", rec)
+			}
+
+			// Следующий вызов отвечает из хранилища без запросов к шлюзу
+			if _, err := registrar.RegisterOrder(context.Background(), request(1500, 398)); err != nil {
+				t.Fatal(err)
+			}
+			if api.registers.Load() != 1 || api.statuses.Load() != 1 {
+				t.Errorf("register calls = %d, status calls = %d; want 1, 1", api.registers.Load(), api.statuses.Load())
+			}
+		})
+	}
+}
+
+func TestRecoverDuplicateMismatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		currency int
+	}{
+		{"amount", 1600, 398},
+		{"currency", 1500, 840},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeAPI{
+				duplicate:  code.ErrorDuplicateOrder,
+				registered: map[string]bool{"A-1": true},
+				status:     core.OrderStatusResponse{OrderID: "id-A-1", OrderNumber: "A-1", Amount: 1500, Currency: 398},
+			}
+			store := NewMemoryStore()
+			registrar := New(api, store, Config{FormURL: formURL})
+
+			resp, err := registrar.RegisterOrder(context.Background(), request(tt.amount, tt.currency))
+			if !errors.Is(err, ErrOrderMismatch) {
+				t.Fatalf("err = %v, want ErrOrderMismatch", err)
+			}
+			if !resp.ErrorCode.IsDuplicateOrder() {
+				t.Errorf("response error code = %d, want the gateway duplicate", resp.ErrorCode)
+			}
+			if _, ok, _ := store.Get(context.Background(), "A-1"); ok {
+				t.Error("mismatched order was saved")
+			}
+		})
+	}
+}
+
+func TestStoredOrderMismatch(t *testing.T) {
+	api := &fakeAPI{}
+	registrar := New(api, NewMemoryStore(), Config{})
+
+	if _, err := registrar.RegisterOrder(context.Background(), request(1500, 398)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registrar.RegisterOrder(context.Background(), request(1500.01, 398)); !errors.Is(err, ErrOrderMismatch) {
+		t.Errorf("err = %v, want ErrOrderMismatch", err)
+	}
+	if got := api.registers.Load(); got != 1 {
+		t.Errorf("register calls = %d, want 1", got)
+	}
+}
+
+func TestRecoverWithoutFormURL(t *testing.T) {
+	api := &fakeAPI{
+		duplicate:  code.ErrorDuplicateOrder,
+		registered: map[string]bool{"A-1": true},
+		status:     core.OrderStatusResponse{OrderID: "id-A-1", OrderNumber: "A-1", Amount: 1500, Currency: 398},
+	}
+	registrar := New(api, NewMemoryStore(), Config{})
+
+	resp, err := registrar.RegisterOrder(context.Background(), request(1500, 398))
+	if !errors.Is(err, ErrFormURLUnknown) {
+		t.Fatalf("err = %v, want ErrFormURLUnknown", err)
+	}
+	if resp.OrderID != "id-A-1" {
+		t.Errorf("OrderID = %q, want id-A-1", resp.OrderID)
+	}
+}
+
+func TestConcurrentRegistration(t *testing.T) {
+	api := &fakeAPI{duplicate: code.ErrorDuplicateOrder, delay: 5 * time.Millisecond}
+	registrar := New(api, NewMemoryStore(), Config{FormURL: formURL})
+
+	const callers = 10
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		orderIDs = map[string]int{}
+	)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := registrar.RegisterOrder(context.Background(), request(1500, 398))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			orderIDs[resp.OrderID]++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	// Вызовы с одним номером выполняются по очереди: шлюз видит одну регистрацию
+	if got := api.registers.Load(); got != 1 {
+		t.Errorf("register calls = %d, want 1", got)
+	}
+	if got := api.maxFlight.Load(); got != 1 {
+		t.Errorf("concurrent register calls = %d, want 1", got)
+	}
+	if orderIDs["id-A-1"] != callers {
+		t.Errorf("order IDs = %v, want id-A-1 for all %d callers", orderIDs, callers)
+	}
+}
@@ -0,0 +1,111 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/internal/jsonl"
+)
+
+// Record — сохранённый результат регистрации заказа.
+type Record struct {
+	OrderNumber string    `json:"order_number"` // Номер заказа в системе мерчанта
+	OrderID     string    `json:"order_id"`     // ID заказа в платёжном шлюзе
+	FormURL     string    `json:"form_url"`     // URL платёжной формы
+	Amount      float64   `json:"amount"`       // Сумма в основных единицах валюты
+	Currency    int       `json:"currency"`     // Код валюты (ISO 4217)
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// OrderStore — хранилище соответствий orderNumber → OrderID/FormURL.
+// Реализация должна быть безопасна для конкурентного использования.
+type OrderStore interface {
+	// Get — поиск записи по номеру заказа; ok = false, если запись отсутствует.
+	Get(ctx context.Context, orderNumber string) (rec Record, ok bool, err error)
+
+	// Put — сохранение записи (перезаписывает существующую с тем же номером).
+	Put(ctx context.Context, rec Record) error
+}
+
+// MemoryStore — хранилище в памяти процесса.
+// Подходит для тестов и одиночных инстансов без требований к переживанию рестарта.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	records map[string]Record
+}
+
+var _ OrderStore = (*MemoryStore)(nil)
+
+// NewMemoryStore — создание хранилища в памяти.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: map[string]Record{}}
+}
+
+// Get — поиск записи по номеру заказа.
+func (s *MemoryStore) Get(_ context.Context, orderNumber string) (Record, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rec, ok := s.records[orderNumber]
+	return rec, ok, nil
+}
+
+// Put — сохранение записи.
+func (s *MemoryStore) Put(_ context.Context, rec Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[rec.OrderNumber] = rec
+	return nil
+}
+
+// FileStore — файловое хранилище (append-only JSON Lines).
+// Каждая запись сбрасывается на диск до возврата из Put; при открытии
+// состояние восстанавливается из файла (последняя запись с номером побеждает).
+type FileStore struct {
+	mu      sync.Mutex
+	file    *jsonl.File
+	records map[string]Record
+}
+
+var _ OrderStore = (*FileStore)(nil)
+
+// OpenFileStore — открывает (или создаёт) файл хранилища и загружает записи из него.
+// Обрезанная при падении последняя строка удаляется из файла.
+func OpenFileStore(path string) (*FileStore, error) {
+	s := &FileStore{records: map[string]Record{}}
+
+	file, err := jsonl.Open(path, func(rec Record) {
+		s.records[rec.OrderNumber] = rec
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.file = file
+	return s, nil
+}
+
+// Close — закрывает файл хранилища.
+func (s *FileStore) Close() error {
+	return s.file.Close()
+}
+
+// Get — поиск записи по номеру заказа.
+func (s *FileStore) Get(_ context.Context, orderNumber string) (Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, ok := s.records[orderNumber]
+	return rec, ok, nil
+}
+
+// Put — сохранение записи с синхронизацией файла на диск.
+func (s *FileStore) Put(_ context.Context, rec Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.file.Append(rec); err != nil {
+		return err
+	}
+
+	s.records[rec.OrderNumber] = rec
+	return nil
+}
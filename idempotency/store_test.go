@@ -0,0 +1,42 @@
+package idempotency
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStoreTornLine(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "orders.jsonl")
+	content := `{"order_number":"A-1","order_id":"id-1","amount":100,"currency":398}` + "\n" + `{"order_number":"A-2","ord`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(ctx, Record{OrderNumber: "A-3", OrderID: "id-3"}); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	store, err = OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	for number, wantID := range map[string]string{"A-1": "id-1", "A-3": "id-3"} {
+		rec, ok, err := store.Get(ctx, number)
+		if err != nil || !ok || rec.OrderID != wantID {
+			t.Errorf("Get(%s) = %+v, %v, %v; want order id %s", number, rec, ok, err, wantID)
+		}
+	}
+	if _, ok, _ := store.Get(ctx, "A-2"); ok {
+		t.Error("torn record A-2 must not be loaded")
+	}
+}
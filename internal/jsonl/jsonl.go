@@ -0,0 +1,97 @@
+// Package jsonl — append-only файл JSON Lines с синхронизацией каждой записи на диск.
+// Используется журналом пакетных операций и файловым хранилищем идемпотентности.
+package jsonl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+)
+
+// File — открытый файл JSON Lines. Не безопасен для конкурентного использования:
+// вызывающий код сериализует Append своей блокировкой.
+type File struct {
+	file *os.File
+}
+
+// Open — открывает (или создаёт) файл и передаёт в load каждую сохранённую запись по порядку.
+//
+// Строки с некорректным JSON пропускаются. Незавершённая последняя строка (без '\n')
+// остаётся после падения процесса посреди записи: файл обрезается до последнего '\n',
+// чтобы следующая запись не склеилась с ней.
+func Open[T any](path string, load func(T)) (*File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := scan(file, load)
+	if err == nil {
+		err = truncate(file, size)
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &File{file: file}, nil
+}
+
+// scan — чтение полных строк; возвращает смещение конца последней полной строки.
+func scan[T any](file *os.File, load func(T)) (int64, error) {
+	var size int64
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return size, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		size += int64(len(line))
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var entry T
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+		load(entry)
+	}
+}
+
+func truncate(file *os.File, size int64) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == size {
+		return nil
+	}
+	if err := file.Truncate(size); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+// Append — запись значения отдельной строкой с синхронизацией файла на диск.
+func (f *File) Append(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := f.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return f.file.Sync()
+}
+
+// Close — закрывает файл.
+func (f *File) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,104 @@
+package jsonl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type entry struct {
+	Key   string `json:"key"`
+	Value int    `json:"value"`
+}
+
+func TestOpenTruncatesTornLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []entry
+		kept    string
+	}{
+		{
+			name:    "empty",
+			content: "",
+			kept:    "",
+		},
+		{
+			name:    "complete",
+			content: "{\"key\":\"a\",\"value\":1}\n",
+			want:    []entry{{"a", 1}},
+			kept:    "{\"key\":\"a\",\"value\":1}\n",
+		},
+		{
+			name:    "torn json",
+			content: "{\"key\":\"a\",\"value\":1}\n{\"key\":\"b\",\"val",
+			want:    []entry{{"a", 1}},
+			kept:    "{\"key\":\"a\",\"value\":1}\n",
+		},
+		{
+			name:    "valid json without newline",
+			content: "{\"key\":\"a\",\"value\":1}\n{\"key\":\"b\",\"value\":2}",
+			want:    []entry{{"a", 1}},
+			kept:    "{\"key\":\"a\",\"value\":1}\n",
+		},
+		{
+			name:    "only torn line",
+			content: "{\"key\":",
+			kept:    "",
+		},
+		{
+			name:    "corrupted line in the middle",
+			content: "{\"key\":\"a\",\"value\":1}\ngarbage\n{\"key\":\"b\",\"value\":2}\n",
+			want:    []entry{{"a", 1}, {"b", 2}},
+			kept:    "{\"key\":\"a\",\"value\":1}\ngarbage\n{\"key\":\"b\",\"value\":2}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "journal.jsonl")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []entry
+			f, err := Open(path, func(e entry) { got = append(got, e) })
+			if err != nil {
+				t.Fatalf("Open: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("loaded %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("entry %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+
+			if err := f.Append(entry{"c", 3}); err != nil {
+				t.Fatalf("Append: %v", err)
+			}
+			if err := f.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := tt.kept + "{\"key\":\"c\",\"value\":3}\n"; string(data) != want {
+				t.Errorf("file = %q, want %q", data, want)
+			}
+
+			var reloaded []entry
+			f, err = Open(path, func(e entry) { reloaded = append(reloaded, e) })
+			if err != nil {
+				t.Fatalf("reopen: %v", err)
+			}
+			f.Close()
+			if len(reloaded) != len(tt.want)+1 || reloaded[len(reloaded)-1] != (entry{"c", 3}) {
+				t.Errorf("reloaded %v, want %v followed by the appended entry", reloaded, tt.want)
+			}
+		})
+	}
+}
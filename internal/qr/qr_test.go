@@ -0,0 +1,378 @@
+package qr
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEncodeRoundTrip(t *testing.T) {
+	binary := make([]byte, 300)
+	for i := range binary {
+		binary[i] = byte(i * 7)
+	}
+
+	payloads := map[string][]byte{
+		"empty":  {},
+		"short":  []byte("https://qr.example/pay?id=1"),
+		"medium": []byte(strings.Repeat("00020101021226", 8)),
+		"binary": binary,
+	}
+	levels := map[string]Level{"L": L, "M": M, "Q": Q, "H": H}
+
+	for levelName, level := range levels {
+		for payloadName, payload := range payloads {
+			t.Run(levelName+"/"+payloadName, func(t *testing.T) {
+				code, err := Encode(payload, level)
+				if err != nil {
+					t.Fatalf("Encode: %v", err)
+				}
+
+				gotLevel, data, err := decode(code)
+				if err != nil {
+					t.Fatalf("decode: %v", err)
+				}
+				if gotLevel != level {
+					t.Errorf("level = %d, want %d", gotLevel, level)
+				}
+				if !bytes.Equal(data, payload) {
+					t.Errorf("data = %q, want %q", data, payload)
+				}
+			})
+		}
+	}
+}
+
+func TestEncodeTooLong(t *testing.T) {
+	if _, err := Encode(make([]byte, 3000), L); err != ErrTooLong {
+		t.Fatalf("err = %v, want ErrTooLong", err)
+	}
+}
+
+func TestAlignmentPositions(t *testing.T) {
+	// Значения из приложения E стандарта ISO/IEC 18004
+	tests := map[int][]int{
+		1:  nil,
+		2:  {6, 18},
+		7:  {6, 22, 38},
+		14: {6, 26, 46, 66},
+		32: {6, 34, 60, 86, 112, 138},
+		40: {6, 30, 58, 86, 114, 142, 170},
+	}
+	for version, want := range tests {
+		if got := alignmentPositions(version); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("version %d: positions = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestFormatInfo(t *testing.T) {
+	// Значения из приложения C стандарта ISO/IEC 18004
+	tests := []struct {
+		level Level
+		mask  int
+		want  int
+	}{
+		{L, 0, 0x77C4},
+		{M, 0, 0x5412},
+		{Q, 0, 0x355F},
+		{Q, 7, 0x2BED},
+		{H, 0, 0x1689},
+	}
+	for _, tt := range tests {
+		if got := formatInfo(tt.level, tt.mask); got != tt.want {
+			t.Errorf("level %d mask %d: format = %#04x, want %#04x", tt.level, tt.mask, got, tt.want)
+		}
+	}
+}
+
+// ------------------------------------------------------------
+// Декодер для проверки: читает матрицу только через Size и Dark
+// ------------------------------------------------------------
+
+// decode — декодирование QR-кода в байтовом режиме с проверкой кодов Рида — Соломона.
+func decode(c *Code) (Level, []byte, error) {
+	if (c.Size-17)%4 != 0 {
+		return 0, nil, fmt.Errorf("invalid size %d", c.Size)
+	}
+	version := (c.Size - 17) / 4
+
+	if !c.Dark(8, c.Size-8) {
+		return 0, nil, fmt.Errorf("dark module is light")
+	}
+	if version >= 7 {
+		if got := readVersion(c); got != version {
+			return 0, nil, fmt.Errorf("version info = %d, want %d", got, version)
+		}
+	}
+
+	level, mask, err := readFormat(c)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	reserved := functionModules(c.Size, version)
+	var bits []bool
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !reserved[y][x] {
+					bits = append(bits, c.Dark(x, y) != masked(mask, x, y))
+				}
+			}
+		}
+	}
+
+	codewords := make([]byte, len(bits)/8)
+	for i := range codewords {
+		for _, b := range bits[i*8 : i*8+8] {
+			codewords[i] <<= 1
+			if b {
+				codewords[i] |= 1
+			}
+		}
+	}
+
+	data, err := deinterleave(codewords, version, level)
+	if err != nil {
+		return 0, nil, err
+	}
+	payload, err := parseByteMode(data, version)
+	return level, payload, err
+}
+
+// readFormat — уровень коррекции и маска из основной копии формата, сверенные со второй копией.
+func readFormat(c *Code) (Level, int, error) {
+	var first, second int
+	for i := 0; i <= 5; i++ {
+		first |= b2i(c.Dark(8, i)) << i
+	}
+	first |= b2i(c.Dark(8, 7)) << 6
+	first |= b2i(c.Dark(8, 8)) << 7
+	first |= b2i(c.Dark(7, 8)) << 8
+	for i := 9; i < 15; i++ {
+		first |= b2i(c.Dark(14-i, 8)) << i
+	}
+	for i := 0; i < 8; i++ {
+		second |= b2i(c.Dark(c.Size-1-i, 8)) << i
+	}
+	for i := 8; i < 15; i++ {
+		second |= b2i(c.Dark(8, c.Size-15+i)) << i
+	}
+	if first != second {
+		return 0, 0, fmt.Errorf("format copies differ: %#04x != %#04x", first, second)
+	}
+
+	for _, level := range []Level{L, M, Q, H} {
+		for mask := 0; mask < 8; mask++ {
+			if formatInfo(level, mask) == first {
+				return level, mask, nil
+			}
+		}
+	}
+	return 0, 0, fmt.Errorf("unknown format info %#04x", first)
+}
+
+// formatInfo — 15 бит формата: уровень, маска и код БЧХ (15, 5).
+func formatInfo(level Level, mask int) int {
+	data := [4]int{1, 0, 3, 2}[level]<<3 | mask
+	rem := data << 10
+	for i := 14; i >= 10; i-- {
+		if rem&(1<<i) != 0 {
+			rem ^= 0x537 << (i - 10)
+		}
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// readVersion — номер версии из блока 6x3 у правого верхнего угла.
+func readVersion(c *Code) int {
+	bits := 0
+	for i := 0; i < 18; i++ {
+		bits |= b2i(c.Dark(c.Size-11+i%3, i/3)) << i
+	}
+	return bits >> 12
+}
+
+// functionModules — карта служебных модулей: поисковые узоры с разделителями и областью
+// формата, синхронизирующие линии, выравнивающие узоры и область версии.
+func functionModules(size, version int) [][]bool {
+	grid := newGrid(size)
+	mark := func(x0, y0, x1, y1 int) {
+		for y := y0; y < y1; y++ {
+			for x := x0; x < x1; x++ {
+				grid[y][x] = true
+			}
+		}
+	}
+
+	mark(0, 0, 9, 9)
+	mark(size-8, 0, size, 9)
+	mark(0, size-8, 9, size)
+	mark(6, 0, 7, size)
+	mark(0, 6, size, 7)
+
+	positions := alignmentPositions(version)
+	n := len(positions)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (i == 0 && j == 0) || (i == 0 && j == n-1) || (i == n-1 && j == 0) {
+				continue
+			}
+			mark(positions[i]-2, positions[j]-2, positions[i]+3, positions[j]+3)
+		}
+	}
+
+	if version >= 7 {
+		mark(size-11, 0, size-8, 6)
+		mark(0, size-11, 6, size-8)
+	}
+	return grid
+}
+
+// masked — условие инверсии модуля для маски (таблица 10 стандарта, i — строка, j — столбец).
+func masked(mask, j, i int) bool {
+	switch mask {
+	case 0:
+		return (i+j)%2 == 0
+	case 1:
+		return i%2 == 0
+	case 2:
+		return j%3 == 0
+	case 3:
+		return (i+j)%3 == 0
+	case 4:
+		return (i/2+j/3)%2 == 0
+	case 5:
+		return (i*j)%2+(i*j)%3 == 0
+	case 6:
+		return ((i*j)%2+(i*j)%3)%2 == 0
+	default:
+		return ((i+j)%2+(i*j)%3)%2 == 0
+	}
+}
+
+// deinterleave — разбор кодовых слов по блокам, проверка синдромов и сборка данных.
+func deinterleave(codewords []byte, version int, level Level) ([]byte, error) {
+	numBlocks := numErrorCorrectionBlocks[level][version]
+	eccLen := eccCodewordsPerBlock[level][version]
+	numLong := len(codewords) % numBlocks
+	shortData := len(codewords)/numBlocks - eccLen
+
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i <= shortData; i++ {
+		for j := range blocks {
+			if i < shortData || j >= numBlocks-numLong {
+				blocks[j] = append(blocks[j], codewords[k])
+				k++
+			}
+		}
+	}
+	for i := 0; i < eccLen; i++ {
+		for j := range blocks {
+			blocks[j] = append(blocks[j], codewords[k])
+			k++
+		}
+	}
+
+	var data []byte
+	for j, block := range blocks {
+		for i := 0; i < eccLen; i++ {
+			if s := syndrome(block, i); s != 0 {
+				return nil, fmt.Errorf("block %d: syndrome %d = %#02x", j, i, s)
+			}
+		}
+		data = append(data, block[:len(block)-eccLen]...)
+	}
+	return data, nil
+}
+
+// syndrome — значение многочлена блока в точке α^i поля GF(2^8).
+func syndrome(block []byte, i int) byte {
+	x := byte(1)
+	for n := 0; n < i; n++ {
+		x = gfMulTest(x, 2)
+	}
+	var result byte
+	for _, b := range block {
+		result = gfMulTest(result, x) ^ b
+	}
+	return result
+}
+
+// gfMulTest — умножение «в столбик» по модулю 0x11D, независимое от gfMul кодировщика.
+func gfMulTest(a, b byte) byte {
+	var result byte
+	for b != 0 {
+		if b&1 != 0 {
+			result ^= a
+		}
+		carry := a&0x80 != 0
+		a <<= 1
+		if carry {
+			a ^= 0x1D
+		}
+		b >>= 1
+	}
+	return result
+}
+
+// parseByteMode — сегмент байтового режима, терминатор и байты заполнения.
+func parseByteMode(data []byte, version int) ([]byte, error) {
+	pos := 0
+	read := func(n int) int {
+		v := 0
+		for i := 0; i < n; i++ {
+			v = v<<1 | int(data[pos>>3]>>(7-uint(pos&7))&1)
+			pos++
+		}
+		return v
+	}
+
+	if mode := read(4); mode != 0x4 {
+		return nil, fmt.Errorf("mode = %#x, want byte mode", mode)
+	}
+	countBits := 8
+	if version > 9 {
+		countBits = 16
+	}
+	count := read(countBits)
+	if (pos+count*8+7)/8 > len(data) {
+		return nil, fmt.Errorf("count %d exceeds capacity", count)
+	}
+
+	payload := make([]byte, count)
+	for i := range payload {
+		payload[i] = byte(read(8))
+	}
+	for remaining := min(4, len(data)*8-pos); remaining > 0; remaining-- {
+		if read(1) != 0 {
+			return nil, fmt.Errorf("non-zero terminator")
+		}
+	}
+	for pos%8 != 0 {
+		read(1)
+	}
+	for i, pad := pos/8, byte(0xEC); i < len(data); i, pad = i+1, pad^0xEC^0x11 {
+		if data[i] != pad {
+			return nil, fmt.Errorf("pad byte %d = %#02x, want %#02x", i, data[i], pad)
+		}
+	}
+	return payload, nil
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiterBurst(t *testing.T) {
+	l := NewLimiter(1, 3)
+	for i := 0; i < 3; i++ {
+		if d := l.reserve(); d != 0 {
+			t.Fatalf("token %d: wait %s, want 0 within burst", i+1, d)
+		}
+	}
+	if d := l.reserve(); d <= 900*time.Millisecond || d > time.Second {
+		t.Errorf("token after burst: wait %s, want about 1s", d)
+	}
+}
+
+func TestLimiterRefill(t *testing.T) {
+	l := NewLimiter(100, 2)
+	l.reserve()
+	l.reserve()
+
+	// Через 20 мс при 100 токенах в секунду корзина снова полна, но не больше burst
+	l.last = l.last.Add(-time.Second)
+	for i := 0; i < 2; i++ {
+		if d := l.reserve(); d != 0 {
+			t.Fatalf("token %d after refill: wait %s, want 0", i+1, d)
+		}
+	}
+	if d := l.reserve(); d == 0 {
+		t.Error("refill exceeded burst")
+	}
+}
+
+func TestLimiterWaitBlocks(t *testing.T) {
+	l := NewLimiter(20, 1)
+	ctx := context.Background()
+	if err := l.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if err := l.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if waited := time.Since(start); waited < 40*time.Millisecond {
+		t.Errorf("second Wait returned after %s, want about 50ms", waited)
+	}
+}
+
+func TestLimiterWaitDeadline(t *testing.T) {
+	l := NewLimiter(1, 1)
+	l.reserve()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := l.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if time.Since(start) > 5*time.Millisecond {
+		t.Error("Wait blocked although the deadline could not be met")
+	}
+
+	// Отказ от ожидания возвращает зарезервированный токен
+	if l.tokens < -0.01 || l.tokens > 0.01 {
+		t.Errorf("tokens = %.2f after canceled wait, want 0", l.tokens)
+	}
+}
+
+func TestLimiterWaitCanceled(t *testing.T) {
+	l := NewLimiter(1, 1)
+	l.reserve()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if l.tokens < -0.01 {
+		t.Errorf("tokens = %.2f after canceled wait, want the reservation returned", l.tokens)
+	}
+}
+
+func TestSemaphore(t *testing.T) {
+	s := NewSemaphore(2)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := s.Acquire(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := s.Acquire(timeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquire over the limit: err = %v, want context.DeadlineExceeded", err)
+	}
+
+	acquired := make(chan error, 1)
+	go func() { acquired <- s.Acquire(ctx) }()
+	s.Release()
+	select {
+	case err := <-acquired:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Release did not unblock a waiting Acquire")
+	}
+	if len(s) != 2 {
+		t.Errorf("slots in use = %d, want 2", len(s))
+	}
+}
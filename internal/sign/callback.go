@@ -0,0 +1,47 @@
+package sign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Параметры колбэка, которые не входят в контрольную сумму.
+const (
+	ChecksumParam  = "checksum"
+	SignAliasParam = "sign_alias"
+)
+
+// CallbackString — строка для контрольной суммы колбэка: все параметры, кроме checksum
+// и sign_alias, отсортированные по имени, в виде "имя;значение;" без разделителей между парами.
+//
+//	amount;1000;mdOrder;3ff6962a-...;operation;deposited;orderNumber;10747;status;1;
+func CallbackString(values url.Values) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		if name == ChecksumParam || name == SignAliasParam {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(';')
+		b.WriteString(values.Get(name))
+		b.WriteByte(';')
+	}
+	return b.String()
+}
+
+// HMACSHA256 — HMAC-SHA256 строки в шестнадцатеричном виде (верхний регистр).
+func HMACSHA256(key, data string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(data))
+	return strings.ToUpper(hex.EncodeToString(mac.Sum(nil)))
+}
@@ -0,0 +1,63 @@
+package sign
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/url"
+	"testing"
+)
+
+func TestCallbackString(t *testing.T) {
+	values := url.Values{
+		"status":       {"1"},
+		"orderNumber":  {"10747"},
+		"mdOrder":      {"3ff6962a-7dcc-4283-ab50-a6d7dd3386fe"},
+		"operation":    {"deposited"},
+		"amount":       {"1000"},
+		ChecksumParam:  {"ABCDEF"},
+		SignAliasParam: {"test"},
+	}
+
+	want := "amount;1000;mdOrder;3ff6962a-7dcc-4283-ab50-a6d7dd3386fe;operation;deposited;orderNumber;10747;status;1;"
+	if got := CallbackString(values); got != want {
+		t.Errorf("CallbackString = %q, want %q", got, want)
+	}
+}
+
+func TestHMACSHA256(t *testing.T) {
+	tests := []struct {
+		key, data, want string
+	}{
+		{"key", "The quick brown fox jumps over the lazy dog", "F7BC83F430538424B13298E6AA6FB143EF4D59A14946175997479DBC2D1A3CD8"},
+		{
+			"secret",
+			"amount;1000;mdOrder;3ff6962a-7dcc-4283-ab50-a6d7dd3386fe;operation;deposited;orderNumber;10747;status;1;",
+			"70975C0E126D1A04EF7399B76C4C18F23BF253E3DF784EEFB05CB3A7A96BF0B2",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := HMACSHA256(tt.key, tt.data); got != tt.want {
+			t.Errorf("HMACSHA256(%q, %q) = %s, want %s", tt.key, tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestSignDigest(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := SHA256("amount;1000;status;1;")
+	signature, err := SignDigest(key, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyDigest(&key.PublicKey, digest, signature); err != nil {
+		t.Errorf("valid signature rejected: %v", err)
+	}
+	if err := VerifyDigest(&key.PublicKey, SHA256("amount;1;status;1;"), signature); err == nil {
+		t.Error("signature accepted for another digest")
+	}
+}
@@ -0,0 +1,76 @@
+// Package sign — подпись данных ключом мерчанта (RSA, SHA-256).
+// Используется для подписи запросов к шлюзу в PROD-режиме и колбэков в тестовых утилитах.
+package sign
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+)
+
+// SHA256 — хэш SHA-256 строки.
+func SHA256(data string) []byte {
+	hash := sha256.Sum256([]byte(data))
+	return hash[:]
+}
+
+// SHA256Base64 — хэш SHA-256 строки в base64.
+func SHA256Base64(data string) string {
+	return base64.StdEncoding.EncodeToString(SHA256(data))
+}
+
+// LoadEncryptedPrivateKey — декодирует и расшифровывает PEM-зашифрованный ключ.
+func LoadEncryptedPrivateKey(path string, password []byte) (*rsa.PrivateKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM")
+	}
+
+	der, err := x509.DecryptPEMBlock(block, password)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKCS1PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// SignDigest — подпись хэша SHA-256 (RSA PKCS #1 v1.5).
+func SignDigest(privateKey *rsa.PrivateKey, digest []byte) ([]byte, error) {
+	return rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest)
+}
+
+// SignSHA256 — подпись хэша SHA-256, переданного в base64. Подпись возвращается в base64.
+func SignSHA256(privateKey *rsa.PrivateKey, hashBase64 string) (string, error) {
+	hashBytes, err := base64.StdEncoding.DecodeString(hashBase64)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := SignDigest(privateKey, hashBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// VerifyDigest — проверка подписи хэша SHA-256 (RSA PKCS #1 v1.5).
+func VerifyDigest(publicKey *rsa.PublicKey, digest, signature []byte) error {
+	return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest, signature)
+}
@@ -0,0 +1,51 @@
+package bereke_merchant
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/models/code"
+)
+
+// RequestMetrics — данные об одном запросе к шлюзу для системы метрик.
+type RequestMetrics struct {
+	Endpoint   string          // Endpoint шлюза (например, "register.do")
+	Duration   time.Duration   // Время выполнения запроса (без ожидания лимитов)
+	HTTPStatus int             // HTTP-статус ответа (0 — ответ не получен)
+	ErrorCode  code.ErrorCode  // Код ошибки шлюза (errorCode)
+	ActionCode code.ActionCode // Код ответа процессинга (actionCode), если есть в ответе
+	Err        error           // Ошибка запроса (сеть, таймаут, декодирование, ErrCircuitOpen)
+}
+
+// MetricsRecorder — приёмник метрик запросов к шлюзу.
+// Готовые адаптеры: пакеты metrics/prometheus и metrics/otel (отдельные модули,
+// чтобы основной модуль оставался без внешних зависимостей).
+type MetricsRecorder interface {
+	RecordRequest(ctx context.Context, m RequestMetrics)
+}
+
+// WithMetrics — подключение приёмника метрик к клиенту.
+func WithMetrics(recorder MetricsRecorder) Option {
+	return func(a *api) {
+		a.metrics = recorder
+	}
+}
+
+// recordMetrics — отправка метрик запроса, если приёмник подключён.
+func (a *api) recordMetrics(ctx context.Context, m RequestMetrics) {
+	if a.metrics != nil {
+		a.metrics.RecordRequest(ctx, m)
+	}
+}
+
+// actionCoder — ответ шлюза с кодом процессинга (actionCode).
+type actionCoder interface {
+	GatewayActionCode() code.ActionCode
+}
+
+func gatewayActionCode(result interface{}) code.ActionCode {
+	if c, ok := result.(actionCoder); ok {
+		return c.GatewayActionCode()
+	}
+	return 0
+}
@@ -0,0 +1,11 @@
+module github.com/bsagat/bereke-merchant-api/metrics/otel
+
+go 1.24.0
+
+require (
+	github.com/bsagat/bereke-merchant-api v0.0.0
+	go.opentelemetry.io/otel v1.38.0
+	go.opentelemetry.io/otel/metric v1.38.0
+)
+
+replace github.com/bsagat/bereke-merchant-api => ../..
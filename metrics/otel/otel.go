@@ -0,0 +1,60 @@
+// Package otel — адаптер метрик запросов к Bereke Merchant API для OpenTelemetry.
+//
+//	recorder, err := otel.New(otelglobal.Meter("payments"))
+//	api, err := bereke_merchant.NewWithLogin(login, password, types.PROD, bereke_merchant.WithMetrics(recorder))
+package otel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+)
+
+// Recorder — реализация bereke.MetricsRecorder поверх OpenTelemetry Metrics.
+//
+// Инструменты:
+//   - bereke.requests — счётчик запросов
+//   - bereke.request.duration — гистограмма времени запросов (секунды)
+//
+// Атрибуты: bereke.endpoint, http.response.status_code, bereke.error_code, bereke.action_code.
+type Recorder struct {
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+var _ bereke.MetricsRecorder = (*Recorder)(nil)
+
+// New — создание адаптера на основе meter.
+func New(meter metric.Meter) (*Recorder, error) {
+	requests, err := meter.Int64Counter("bereke.requests",
+		metric.WithDescription("Количество запросов к Bereke Merchant API."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram("bereke.request.duration",
+		metric.WithDescription("Время выполнения запросов к Bereke Merchant API."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{requests: requests, duration: duration}, nil
+}
+
+// RecordRequest — реализация bereke.MetricsRecorder.
+func (r *Recorder) RecordRequest(ctx context.Context, m bereke.RequestMetrics) {
+	attrs := metric.WithAttributes(
+		attribute.String("bereke.endpoint", m.Endpoint),
+		attribute.Int("http.response.status_code", m.HTTPStatus),
+		attribute.Int("bereke.error_code", int(m.ErrorCode)),
+		attribute.Int("bereke.action_code", int(m.ActionCode)),
+	)
+
+	r.requests.Add(ctx, 1, attrs)
+	r.duration.Record(ctx, m.Duration.Seconds(), attrs)
+}
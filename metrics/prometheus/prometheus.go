@@ -0,0 +1,77 @@
+// Package prometheus — адаптер метрик запросов к Bereke Merchant API для Prometheus.
+//
+//	recorder, err := prometheus.New(prom.DefaultRegisterer)
+//	api, err := bereke_merchant.NewWithLogin(login, password, types.PROD, bereke_merchant.WithMetrics(recorder))
+package prometheus
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+)
+
+// Recorder — реализация bereke.MetricsRecorder поверх Prometheus.
+//
+// Метрики:
+//   - bereke_requests_total{endpoint, http_status, error_code, action_code} — количество запросов
+//   - bereke_request_duration_seconds{endpoint, http_status} — гистограмма времени запросов
+//
+// http_status = "0" означает, что ответ не получен (сетевая ошибка, таймаут, ErrCircuitOpen).
+type Recorder struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+var _ bereke.MetricsRecorder = (*Recorder)(nil)
+
+// Options — настройки адаптера.
+type Options struct {
+	Namespace string    // Префикс имён метрик (по умолчанию "bereke")
+	Buckets   []float64 // Границы гистограммы в секундах (по умолчанию prometheus.DefBuckets)
+}
+
+// New — создание адаптера и регистрация метрик в reg.
+func New(reg prometheus.Registerer, opts ...Options) (*Recorder, error) {
+	var o Options
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.Namespace == "" {
+		o.Namespace = "bereke"
+	}
+	if o.Buckets == nil {
+		o.Buckets = prometheus.DefBuckets
+	}
+
+	r := &Recorder{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: o.Namespace,
+			Name:      "requests_total",
+			Help:      "Количество запросов к Bereke Merchant API.",
+		}, []string{"endpoint", "http_status", "error_code", "action_code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: o.Namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Время выполнения запросов к Bereke Merchant API.",
+			Buckets:   o.Buckets,
+		}, []string{"endpoint", "http_status"}),
+	}
+
+	for _, c := range []prometheus.Collector{r.requests, r.duration} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// RecordRequest — реализация bereke.MetricsRecorder.
+func (r *Recorder) RecordRequest(_ context.Context, m bereke.RequestMetrics) {
+	status := strconv.Itoa(m.HTTPStatus)
+
+	r.requests.WithLabelValues(m.Endpoint, status, strconv.Itoa(int(m.ErrorCode)), strconv.Itoa(int(m.ActionCode))).Inc()
+	r.duration.WithLabelValues(m.Endpoint, status).Observe(m.Duration.Seconds())
+}
@@ -0,0 +1,289 @@
+package code
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Category — категория кода ответа шлюза.
+type Category string
+
+const (
+	CategoryUnknown   Category = ""          // Код не описан
+	CategorySuccess   Category = "success"   // Операция успешна
+	CategoryPending   Category = "pending"   // Операция в процессе
+	CategoryDecline   Category = "decline"   // Отказ эмитента или ошибка в данных карты
+	CategoryFraud     Category = "fraud"     // Отказ по подозрению в мошенничестве
+	CategoryTechnical Category = "technical" // Технический сбой, операцию можно повторить
+	Category3DS       Category = "3ds"       // Ошибка 3-D Secure
+	CategoryLimit     Category = "limit"     // Превышен лимит
+	CategoryRequest   Category = "request"   // Ошибка интеграции или некорректный запрос мерчанта
+)
+
+// Языки описаний.
+const (
+	LangRu = "ru"
+	LangKk = "kk"
+	LangEn = "en"
+)
+
+// Description — описание кода ответа шлюза.
+type Description struct {
+	Code     int // Числовое значение ErrorCode или ActionCode
+	Category Category
+	Customer string // Текст для покупателя (не раскрывает причин отказа по мошенничеству)
+	Merchant string // Текст для мерчанта и службы поддержки
+}
+
+// Describe — описание кода ответа банка (actionCode) на языке lang (ru, kk, en; "kz" — синоним kk).
+// Для неизвестного языка используется русский, для неизвестного кода — общий текст с номером кода.
+// Описание кода ошибки шлюза (errorCode) — ErrorCode(code).Describe: числовые значения
+// двух доменов пересекаются, поэтому Describe всегда трактует code как ActionCode.
+func Describe(code int, lang string) Description {
+	return ActionCode(code).Describe(lang)
+}
+
+// String — имя константы (например, "InsufficientFunds") или "ActionCode(N)" для неизвестных кодов.
+func (c ActionCode) String() string {
+	if name, ok := actionCodeNames[c]; ok {
+		return name
+	}
+	return "ActionCode(" + strconv.Itoa(int(c)) + ")"
+}
+
+// IsSuccess — операция в процессинге прошла успешно.
+func (c ActionCode) IsSuccess() bool {
+	return c == Success
+}
+
+// IsRetryable — технический сбой процессинга или эмитента, оплату можно повторить.
+func (c ActionCode) IsRetryable() bool {
+	return c.Category() == CategoryTechnical
+}
+
+// IsFraud — отказ по подозрению в мошенничестве (повторять оплату той же картой не следует).
+func (c ActionCode) IsFraud() bool {
+	return c.Category() == CategoryFraud
+}
+
+// Category — категория кода (CategoryUnknown для неописанных кодов).
+func (c ActionCode) Category() Category {
+	return descriptions[c].category
+}
+
+// Describe — описание кода на языке lang (ru, kk, en).
+func (c ActionCode) Describe(lang string) Description {
+	return describe(int(c), descriptions[c], lang)
+}
+
+func describe(c int, e entry, lang string) Description {
+	if e.category == CategoryUnknown {
+		return Description{
+			Code:     c,
+			Category: CategoryUnknown,
+			Customer: unknownCustomer.in(lang),
+			Merchant: fmt.Sprintf(unknownMerchant.in(lang), c),
+		}
+	}
+
+	customer := customerTexts[e.category]
+	if e.customer != nil {
+		customer = *e.customer
+	}
+	return Description{
+		Code:     c,
+		Category: e.category,
+		Customer: customer.in(lang),
+		Merchant: e.merchant.in(lang),
+	}
+}
+
+// text — текст на поддерживаемых языках.
+type text struct {
+	ru, kk, en string
+}
+
+func (t text) in(lang string) string {
+	switch lang {
+	case LangKk, "kz":
+		return t.kk
+	case LangEn:
+		return t.en
+	default:
+		return t.ru
+	}
+}
+
+type entry struct {
+	category Category
+	merchant text
+	customer *text // nil — текст категории
+}
+
+var (
+	unknownCustomer = text{"Платёж не выполнен", "Төлем орындалмады", "Payment failed"}
+	unknownMerchant = text{"Неизвестный код ответа %d", "Белгісіз жауап коды %d", "Unknown response code %d"}
+)
+
+// customerTexts — тексты для покупателя по категориям.
+var customerTexts = map[Category]text{
+	CategorySuccess: {"Оплата прошла успешно", "Төлем сәтті өтті", "Payment successful"},
+	CategoryPending: {"Платёж обрабатывается", "Төлем өңделуде", "Payment is being processed"},
+	CategoryDecline: {
+		"Банк отклонил платёж. Попробуйте другую карту или обратитесь в банк",
+		"Банк төлемді қабылдамады. Басқа картаны пайдаланып көріңіз немесе банкке хабарласыңыз",
+		"The bank declined the payment. Try another card or contact your bank",
+	},
+	CategoryFraud: {
+		"Платёж отклонён. Обратитесь в банк, выпустивший карту",
+		"Төлем қабылданбады. Картаны шығарған банкке хабарласыңыз",
+		"Payment declined. Please contact your card issuer",
+	},
+	CategoryTechnical: {
+		"Технический сбой. Попробуйте повторить оплату позже",
+		"Техникалық ақау. Төлемді кейінірек қайталап көріңіз",
+		"Technical error. Please try again later",
+	},
+	Category3DS: {
+		"Не удалось подтвердить платёж (3-D Secure). Попробуйте ещё раз",
+		"Төлемді растау мүмкін болмады (3-D Secure). Қайталап көріңіз",
+		"Payment confirmation (3-D Secure) failed. Please try again",
+	},
+	CategoryLimit: {
+		"Превышен лимит по карте. Попробуйте другую карту или обратитесь в банк",
+		"Карта бойынша лимит асып кетті. Басқа картаны пайдаланып көріңіз немесе банкке хабарласыңыз",
+		"Card limit exceeded. Try another card or contact your bank",
+	},
+	CategoryRequest: {
+		"Не удалось провести платёж. Обратитесь в магазин",
+		"Төлемді жүргізу мүмкін болмады. Дүкенге хабарласыңыз",
+		"The payment could not be processed. Please contact the store",
+	},
+}
+
+// Тексты для покупателя, уточняющие текст категории.
+var (
+	customerInsufficientFunds = &text{"Недостаточно средств на карте", "Картада қаражат жеткіліксіз", "Insufficient funds"}
+	customerCardExpired       = &text{
+		"Срок действия карты истёк или указан неверно",
+		"Картаның қолданылу мерзімі өтіп кеткен немесе қате көрсетілген",
+		"The card has expired or the expiry date is incorrect",
+	}
+	customerInvalidCVC        = &text{"Неверный CVC-код", "CVC коды қате", "Incorrect CVC code"}
+	customerInvalidCardNumber = &text{"Неверный номер карты", "Карта нөмірі қате", "Incorrect card number"}
+	customerCardBlocked       = &text{"Карта заблокирована", "Карта бұғатталған", "The card is blocked"}
+	customerInternetBlocked   = &text{
+		"Интернет-платежи по карте запрещены. Разрешите их в приложении банка",
+		"Карта бойынша интернет-төлемдерге тыйым салынған. Оларды банк қосымшасында рұқсат етіңіз",
+		"Online payments are disabled for this card. Enable them in your banking app",
+	}
+	customerPaymentTimeout = &text{"Время на оплату истекло", "Төлем уақыты өтіп кетті", "Payment time has expired"}
+	customerNotCompleted   = &text{"Оплата не была завершена", "Төлем аяқталмады", "The payment was not completed"}
+	customerOrderCancelled = &text{"Заказ отменён", "Тапсырыс жойылды", "The order has been cancelled"}
+	customerCheckCardData  = &text{
+		"Проверьте введённые данные карты",
+		"Енгізілген карта деректерін тексеріңіз",
+		"Please check the card details you entered",
+	}
+)
+
+// descriptions — описания кодов ActionCode.
+var descriptions = map[ActionCode]entry{
+	PendingReview:              {CategoryPending, text{"Операция на рассмотрении, идёт опрос статуса", "Операция қаралуда, мәртебесі сұралуда", "Operation is under review, status is being polled"}, nil},
+	LimitBlock:                 {CategoryLimit, text{"Блокировка по лимиту", "Лимит бойынша бұғаттау", "Blocked by limit"}, nil},
+	DSerror:                    {Category3DS, text{"Ошибка 3DS", "3DS қатесі", "3DS error"}, nil},
+	DSFrictionlessForbidden:    {Category3DS, text{"Frictionless-аутентификация запрещена", "Frictionless аутентификацияға тыйым салынған", "Frictionless authentication is forbidden"}, nil},
+	DSAuthNotPossibleIssuer:    {Category3DS, text{"3DS-аутентификация невозможна на стороне эмитента", "3DS аутентификациясы эмитент жағында мүмкін емес", "3DS authentication is not possible on the issuer side"}, nil},
+	DSProcessingImpossible:     {Category3DS, text{"Проведение операции по 3DS невозможно", "3DS бойынша операция жүргізу мүмкін емес", "3DS processing is not possible"}, nil},
+	InvalidECI:                 {Category3DS, text{"Неверный ECI", "ECI қате", "Invalid ECI"}, nil},
+	DSAuthNotPossibleAcquirer:  {Category3DS, text{"3DS-аутентификация невозможна на стороне эквайера", "3DS аутентификациясы эквайер жағында мүмкін емес", "3DS authentication is not possible on the acquirer side"}, nil},
+	ClientDidNotReturnFromPage: {CategoryDecline, text{"Клиент не вернулся с платёжной страницы", "Клиент төлем бетінен оралмады", "Customer did not return from the payment page"}, customerNotCompleted},
+	TooManyPaymentAttempts:     {CategoryLimit, text{"Превышено количество попыток оплаты", "Төлем әрекеттерінің саны асып кетті", "Too many payment attempts"}, nil},
+	ClientDidNotReturnFromACS:  {Category3DS, text{"Клиент не вернулся с ACS", "Клиент ACS-тен оралмады", "Customer did not return from ACS"}, nil},
+	PaymentTimeout:             {CategoryDecline, text{"Срок оплаты истёк", "Төлем мерзімі өтті", "Payment time expired"}, customerPaymentTimeout},
+	DSecureFailed:              {Category3DS, text{"3DS-авторизация не пройдена", "3DS авторизациясы өтпеді", "3DS authorization failed"}, nil},
+	InvalidPaymentToken:        {CategoryRequest, text{"Некорректный платёжный токен", "Төлем токені қате", "Invalid payment token"}, nil},
+	WaitingForPaymentAttempt:   {CategoryPending, text{"Ожидание попытки оплаты", "Төлем әрекеті күтілуде", "Waiting for a payment attempt"}, nil},
+
+	Success:                       {CategorySuccess, text{"Платёж прошёл успешно", "Төлем сәтті өтті", "Payment successful"}, nil},
+	IdentityVerificationRequired:  {CategoryDecline, text{"Требуется подтверждение личности", "Жеке басын растау қажет", "Identity verification required"}, nil},
+	CardBlockedUnknown:            {CategoryFraud, text{"Карта заблокирована по неизвестной причине", "Карта белгісіз себеппен бұғатталған", "Card blocked for an unknown reason"}, nil},
+	CardDeclinedUnknown:           {CategoryDecline, text{"Карта отклонена по неизвестной причине", "Карта белгісіз себеппен қабылданбады", "Card declined for an unknown reason"}, nil},
+	OrderNotFound:                 {CategoryRequest, text{"Транзакция не найдена", "Транзакция табылмады", "Transaction not found"}, nil},
+	OperationNotAllowed:           {CategoryRequest, text{"Операция невозможна в текущем состоянии транзакции", "Транзакцияның ағымдағы күйінде операция мүмкін емес", "Operation is not allowed in the current transaction state"}, nil},
+	PermissionDenied:              {CategoryRequest, text{"Запрос недоступен пользователю", "Сұрау пайдаланушыға қолжетімсіз", "Request is not permitted for this user"}, nil},
+	InsufficientFunds:             {CategoryDecline, text{"Недостаточно средств", "Қаражат жеткіліксіз", "Insufficient funds"}, customerInsufficientFunds},
+	SecurityCall:                  {CategoryFraud, text{"Вызов службы безопасности", "Қауіпсіздік қызметіне хабарласу", "Security call"}, nil},
+	CardBlocked:                   {CategoryDecline, text{"Карта заблокирована", "Карта бұғатталған", "Card blocked"}, customerCardBlocked},
+	SecurityViolation:             {CategoryFraud, text{"Нарушение безопасности", "Қауіпсіздік талаптарының бұзылуы", "Security violation"}, nil},
+	CardNotAllowedForTransaction:  {CategoryDecline, text{"Карта не разрешена для данного типа транзакции", "Карта бұл транзакция түріне рұқсат етілмеген", "Card not allowed for this transaction type"}, nil},
+	CardExpiryMismatch:            {CategoryDecline, text{"Срок действия не совпадает", "Қолданылу мерзімі сәйкес келмейді", "Expiry date mismatch"}, customerCardExpired},
+	InvalidAccount:                {CategoryDecline, text{"Недействительная учётная запись", "Жарамсыз есептік жазба", "Invalid account"}, nil},
+	InvalidCVC:                    {CategoryDecline, text{"Неверный CVC", "CVC қате", "Invalid CVC"}, customerInvalidCVC},
+	PreAuthTimeTooLong:            {CategoryRequest, text{"Время предавторизации слишком велико", "Алдын ала авторизация уақыты тым ұзақ", "Pre-authorization period is too long"}, nil},
+	UnknownResponseStatus:         {CategoryTechnical, text{"Неизвестный статус ответа", "Белгісіз жауап мәртебесі", "Unknown response status"}, nil},
+	DuplicateTransmission:         {CategoryTechnical, text{"Дублирование передачи", "Қайталап жіберу", "Duplicate transmission"}, nil},
+	ExceededCardLimit:             {CategoryLimit, text{"Превышен лимит карты", "Карта лимиті асып кетті", "Card limit exceeded"}, nil},
+	CardExpired:                   {CategoryDecline, text{"Карта просрочена", "Картаның мерзімі өтіп кеткен", "Card expired"}, customerCardExpired},
+	IssuerDeclined:                {CategoryDecline, text{"Эмитент отклонил платёж", "Эмитент төлемді қабылдамады", "Issuer declined the payment"}, nil},
+	TooManyPINTries:               {CategoryLimit, text{"Превышено количество попыток ввода PIN", "PIN енгізу әрекеттерінің саны асып кетті", "PIN tries exceeded"}, nil},
+	InvalidTerminalConfig:         {CategoryRequest, text{"Неверная конфигурация терминала", "Терминал конфигурациясы қате", "Invalid terminal configuration"}, nil},
+	InvalidPaymentAmount:          {CategoryRequest, text{"Недопустимая сумма платежа", "Төлем сомасы жарамсыз", "Invalid payment amount"}, nil},
+	InvalidCardNumber:             {CategoryDecline, text{"Неверный номер карты", "Карта нөмірі қате", "Invalid card number"}, customerInvalidCardNumber},
+	InsufficientFundsAlt:          {CategoryDecline, text{"Недостаточно средств", "Қаражат жеткіліксіз", "Insufficient funds"}, customerInsufficientFunds},
+	InvalidPIN:                    {CategoryDecline, text{"Неверный PIN", "PIN қате", "Invalid PIN"}, nil},
+	SecurityViolationAlt:          {CategoryFraud, text{"Нарушение безопасности", "Қауіпсіздік талаптарының бұзылуы", "Security violation"}, nil},
+	IssuerOperationNotAllowed:     {CategoryDecline, text{"Операция не разрешена банком-эмитентом", "Операцияға эмитент банк рұқсат бермеген", "Operation not allowed by the issuer"}, nil},
+	TransactionLimitExceeded:      {CategoryLimit, text{"Превышен лимит транзакции", "Транзакция лимиті асып кетті", "Transaction amount limit exceeded"}, nil},
+	TransactionCountLimitExceeded: {CategoryLimit, text{"Превышено количество транзакций", "Транзакциялар саны асып кетті", "Transaction count limit exceeded"}, nil},
+	TechnicalError:                {CategoryTechnical, text{"Техническая ошибка", "Техникалық қате", "Technical error"}, nil},
+	InvalidCardNumberAlt:          {CategoryDecline, text{"Неверный номер карты", "Карта нөмірі қате", "Invalid card number"}, customerInvalidCardNumber},
+	FraudMonitoringDeclined:       {CategoryFraud, text{"Отклонено фрод-мониторингом", "Фрод-мониторинг қабылдамады", "Declined by fraud monitoring"}, nil},
+	ServiceNotAllowed:             {CategoryDecline, text{"Сервис не разрешён", "Қызметке рұқсат етілмеген", "Service not allowed"}, nil},
+	CardLost:                      {CategoryFraud, text{"Карта утеряна", "Карта жоғалған", "Card reported lost"}, nil},
+	CardLostAlt:                   {CategoryFraud, text{"Карта утеряна", "Карта жоғалған", "Card reported lost"}, nil},
+	CardLostRestriction:           {CategoryFraud, text{"Ограничение использования карты", "Картаны пайдалануға шектеу", "Card usage restricted"}, nil},
+	ParticipantBlocked:            {CategoryFraud, text{"Участник заблокирован", "Қатысушы бұғатталған", "Participant blocked"}, nil},
+	RecurringPaymentStopped:       {CategoryDecline, text{"Рекуррентные платежи по карте остановлены", "Карта бойынша рекуррентті төлемдер тоқтатылған", "Recurring payments stopped for this card"}, nil},
+	CardInternetBlocked:           {CategoryDecline, text{"Интернет-транзакции по карте запрещены", "Карта бойынша интернет-транзакцияларға тыйым салынған", "Online transactions are disabled for this card"}, customerInternetBlocked},
+	TransactionCycleLimitExceeded: {CategoryLimit, text{"Превышен предел количества транзакций за период", "Кезеңдегі транзакциялар санының шегі асып кетті", "Transaction count limit for the period exceeded"}, nil},
+	CardReportedStolen:            {CategoryFraud, text{"Карта украдена", "Карта ұрланған", "Card reported stolen"}, nil},
+	BalanceOrLimitExceeded:        {CategoryLimit, text{"Превышен баланс или лимит", "Баланс немесе лимит асып кетті", "Balance or limit exceeded"}, nil},
+	InvalidMessageFormatIssuer:    {CategoryTechnical, text{"Неверный формат сообщения эмитента", "Эмитент хабарламасының пішімі қате", "Invalid issuer message format"}, nil},
+	CardExpiredAlt:                {CategoryDecline, text{"Карта просрочена", "Картаның мерзімі өтіп кеткен", "Card expired"}, customerCardExpired},
+	IssuerUnavailable:             {CategoryTechnical, text{"Нет связи с эмитентом", "Эмитентпен байланыс жоқ", "Issuer unavailable"}, nil},
+	UnknownDecline:                {CategoryDecline, text{"Отклонено по неизвестной причине", "Белгісіз себеппен қабылданбады", "Declined for an unknown reason"}, nil},
+	DeclinedContactShop:           {CategoryRequest, text{"Операция отклонена, обратитесь в магазин", "Операция қабылданбады, дүкенге хабарласыңыз", "Operation declined, contact the store"}, nil},
+	BankUnavailable:               {CategoryTechnical, text{"Банк-эмитент недоступен", "Эмитент банк қолжетімсіз", "Issuing bank unavailable"}, nil},
+	InvalidMessageFormatMPS:       {CategoryTechnical, text{"Неверный формат сообщения МПС", "ХТЖ хабарламасының пішімі қате", "Invalid payment system message format"}, nil},
+	OriginalTransactionNotFound:   {CategoryRequest, text{"Оригинальная транзакция не найдена", "Бастапқы транзакция табылмады", "Original transaction not found"}, nil},
+	UnableToProcess:               {CategoryTechnical, text{"Невозможно обработать", "Өңдеу мүмкін емес", "Unable to process"}, nil},
+	CardRestrictions:              {CategoryDecline, text{"Ограничения по карте", "Карта бойынша шектеулер", "Card restrictions"}, nil},
+	InvalidMerchantID:             {CategoryRequest, text{"Неверный идентификатор продавца", "Сатушы идентификаторы қате", "Invalid merchant ID"}, nil},
+	ReconciliationError:           {CategoryTechnical, text{"Ошибка согласования", "Салыстыру қатесі", "Reconciliation error"}, nil},
+	SystemMalfunction:             {CategoryTechnical, text{"Неисправность системы", "Жүйе ақаулығы", "System malfunction"}, nil},
+	OriginalAmountInvalid:         {CategoryRequest, text{"Неверная сумма оригинальной транзакции", "Бастапқы транзакция сомасы қате", "Invalid original amount"}, nil},
+	ServiceUnavailable:            {CategoryTechnical, text{"Услуга недоступна", "Қызмет қолжетімсіз", "Service unavailable"}, nil},
+	SuspectedFraud:                {CategoryFraud, text{"Подозрение на мошенничество", "Алаяқтық күдігі", "Suspected fraud"}, nil},
+	TooManyRequestsSameCardExpiry: {CategoryLimit, text{"Превышено количество запросов по карте (PAN и срок действия)", "Карта бойынша сұраулар саны асып кетті (PAN және мерзім)", "Too many requests for the card (PAN and expiry)"}, nil},
+	TooManyRequestsSameCard:       {CategoryLimit, text{"Превышено количество запросов по карте (PAN)", "Карта бойынша сұраулар саны асып кетті (PAN)", "Too many requests for the card (PAN)"}, nil},
+	TooManyRequestsLinkedCard:     {CategoryLimit, text{"Превышено количество запросов по карте в связке", "Байланыстағы карта бойынша сұраулар саны асып кетті", "Too many requests for the linked card"}, nil},
+	TooManyFailedPayments:         {CategoryLimit, text{"Превышено количество неуспешных оплат", "Сәтсіз төлемдер саны асып кетті", "Too many failed payments"}, nil},
+	TooManyRequestsTerminal:       {CategoryLimit, text{"Превышено количество запросов по карте на терминале", "Терминалда карта бойынша сұраулар саны асып кетті", "Too many card requests on the terminal"}, nil},
+	TooManyRequestsMerchant:       {CategoryLimit, text{"Превышено количество запросов по карте у продавца", "Сатушыда карта бойынша сұраулар саны асып кетті", "Too many card requests for the merchant"}, nil},
+	TooManyRequestsSameAmount:     {CategoryLimit, text{"Превышено количество запросов с той же суммой и валютой", "Сол сома мен валютамен сұраулар саны асып кетті", "Too many requests with the same amount and currency"}, nil},
+	RefundInProgress:              {CategoryPending, text{"Возврат в процессе", "Қайтару орындалуда", "Refund in progress"}, nil},
+	DSRequired:                    {Category3DS, text{"Требуется авторизация 3DS", "3DS авторизациясы қажет", "3DS authorization required"}, nil},
+	InvalidOperation:              {CategoryRequest, text{"Неверная операция", "Операция қате", "Invalid operation"}, nil},
+	DSRequiredForPayment:          {Category3DS, text{"Платежи без 3DS запрещены", "3DS-сіз төлемдерге тыйым салынған", "Payments without 3DS are forbidden"}, nil},
+	OrderAlreadyCancelled:         {CategoryRequest, text{"Заказ уже отменён", "Тапсырыс бұрын жойылған", "Order already cancelled"}, customerOrderCancelled},
+	DSForbidden:                   {Category3DS, text{"3DS запрещён", "3DS-ке тыйым салынған", "3DS is forbidden"}, nil},
+	ProcessingQueueLimitReached:   {CategoryTechnical, text{"Достигнут лимит потоков обработки", "Өңдеу ағындарының лимитіне жетті", "Processing queue limit reached"}, nil},
+	CardBlockedAlt:                {CategoryDecline, text{"Карта заблокирована", "Карта бұғатталған", "Card blocked"}, customerCardBlocked},
+	PaymentDeclinedByMerchant:     {CategoryRequest, text{"Отклонено продавцом", "Сатушы қабылдамады", "Declined by the merchant"}, nil},
+	IssuerDeclinedAResA:           {Category3DS, text{"Эмитент отклонил платёж с ARes=A", "Эмитент ARes=A кезінде төлемді қабылдамады", "Issuer declined with ARes=A"}, nil},
+	DuplicateOrder:                {CategoryRequest, text{"Заказ с таким номером уже зарегистрирован", "Осындай нөмірмен тапсырыс тіркелген", "Order with this number is already registered"}, nil},
+	InvalidPaymentData:            {CategoryDecline, text{"Некоторые данные платежа недействительны", "Төлемнің кейбір деректері жарамсыз", "Some payment data is invalid"}, customerCheckCardData},
+	ProcessingTimeoutSendFailed:   {CategoryTechnical, text{"Таймаут в процессинге: отправка не удалась", "Процессингтегі таймаут: жіберу сәтсіз", "Processing timeout: send failed"}, nil},
+	ProcessingTimeoutNoResponse:   {CategoryTechnical, text{"Таймаут в процессинге: ответ не получен", "Процессингтегі таймаут: жауап алынбады", "Processing timeout: no response"}, nil},
+}
@@ -0,0 +1,125 @@
+package code
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// declaredConstants — имена констант типа typeName, объявленных в исходниках пакета.
+func declaredConstants(t *testing.T, typeName string) []string {
+	t.Helper()
+	paths, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				value := spec.(*ast.ValueSpec)
+				if ident, ok := value.Type.(*ast.Ident); ok && ident.Name == typeName {
+					for _, name := range value.Names {
+						names = append(names, name.Name)
+					}
+				}
+			}
+		}
+	}
+	if len(names) == 0 {
+		t.Fatalf("no %s constants found", typeName)
+	}
+	return names
+}
+
+// checkText — тексты описания заполнены на всех языках.
+func checkText(t *testing.T, name string, e entry) {
+	t.Helper()
+	if e.category == CategoryUnknown {
+		t.Errorf("%s: no description", name)
+		return
+	}
+	customer := customerTexts[e.category]
+	if e.customer != nil {
+		customer = *e.customer
+	}
+	for _, lang := range []string{LangRu, LangKk, LangEn} {
+		if e.merchant.in(lang) == "" {
+			t.Errorf("%s: merchant text in %s is empty", name, lang)
+		}
+		if customer.in(lang) == "" {
+			t.Errorf("%s: customer text in %s is empty", name, lang)
+		}
+	}
+}
+
+func TestActionCodesDescribed(t *testing.T) {
+	byName := map[string]ActionCode{}
+	for c, name := range actionCodeNames {
+		byName[name] = c
+	}
+	for _, name := range declaredConstants(t, "ActionCode") {
+		c, ok := byName[name]
+		if !ok {
+			t.Errorf("%s: missing from actionCodeNames", name)
+			continue
+		}
+		checkText(t, name, descriptions[c])
+	}
+}
+
+func TestErrorCodesDescribed(t *testing.T) {
+	byName := map[string]ErrorCode{}
+	for c, name := range errorCodeNames {
+		byName[name] = c
+	}
+	for _, name := range declaredConstants(t, "ErrorCode") {
+		c, ok := byName[name]
+		if !ok {
+			t.Errorf("%s: missing from errorCodeNames", name)
+			continue
+		}
+		checkText(t, name, errorDescriptions[c])
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		code     int
+		lang     string
+		category Category
+		customer string
+	}{
+		{int(InsufficientFunds), LangKk, CategoryDecline, "Картада қаражат жеткіліксіз"},
+		{int(InsufficientFunds), "kz", CategoryDecline, "Картада қаражат жеткіліксіз"},
+		{int(InsufficientFunds), LangEn, CategoryDecline, "Insufficient funds"},
+		{int(InsufficientFunds), "de", CategoryDecline, "Недостаточно средств на карте"},
+		{123456, LangEn, CategoryUnknown, "Payment failed"},
+	}
+
+	for _, tt := range tests {
+		d := Describe(tt.code, tt.lang)
+		if d.Code != tt.code || d.Category != tt.category || d.Customer != tt.customer {
+			t.Errorf("Describe(%d, %q) = %+v, want category %q, customer %q", tt.code, tt.lang, d, tt.category, tt.customer)
+		}
+	}
+	if d := Describe(123456, LangEn); d.Merchant != "Unknown response code 123456" {
+		t.Errorf("unknown code merchant text = %q", d.Merchant)
+	}
+}
@@ -0,0 +1,76 @@
+package code
+
+import "strconv"
+
+// ErrorCode — код ошибки обработки запроса шлюзом (errorCode в ответе любого метода).
+type ErrorCode int
+
+const (
+	ErrorNone               ErrorCode = 0    // Запрос обработан без ошибок
+	ErrorInvalidOrderNumber ErrorCode = 1    // Заказ с таким номером уже зарегистрирован, либо номер заказа не указан/неверен
+	ErrorOrderDeclined      ErrorCode = 2    // Заказ отклонён из-за ошибки в реквизитах платежа
+	ErrorUnknownCurrency    ErrorCode = 3    // Неизвестная (запрещённая) валюта
+	ErrorMissingParameter   ErrorCode = 4    // Не указан обязательный параметр запроса
+	ErrorInvalidParameter   ErrorCode = 5    // Ошибка значения параметра запроса или доступ запрещён
+	ErrorOrderNotFound      ErrorCode = 6    // Заказ не найден (незарегистрированный orderId)
+	ErrorSystem             ErrorCode = 7    // Системная ошибка шлюза
+	ErrorDuplicateOrder     ErrorCode = 8204 // Заказ-дубль
+)
+
+// errorCodeNames — имена констант ErrorCode для String().
+var errorCodeNames = map[ErrorCode]string{
+	ErrorNone:               "ErrorNone",
+	ErrorInvalidOrderNumber: "ErrorInvalidOrderNumber",
+	ErrorOrderDeclined:      "ErrorOrderDeclined",
+	ErrorUnknownCurrency:    "ErrorUnknownCurrency",
+	ErrorMissingParameter:   "ErrorMissingParameter",
+	ErrorInvalidParameter:   "ErrorInvalidParameter",
+	ErrorOrderNotFound:      "ErrorOrderNotFound",
+	ErrorSystem:             "ErrorSystem",
+	ErrorDuplicateOrder:     "ErrorDuplicateOrder",
+}
+
+// String — имя константы (например, "ErrorOrderNotFound") или "ErrorCode(N)" для неизвестных кодов.
+func (c ErrorCode) String() string {
+	if name, ok := errorCodeNames[c]; ok {
+		return name
+	}
+	return "ErrorCode(" + strconv.Itoa(int(c)) + ")"
+}
+
+// IsSuccess — запрос обработан без ошибок.
+func (c ErrorCode) IsSuccess() bool {
+	return c == ErrorNone
+}
+
+// IsRetryable — ошибка на стороне шлюза, запрос можно повторить.
+func (c ErrorCode) IsRetryable() bool {
+	return c == ErrorSystem
+}
+
+// IsDuplicateOrder — заказ с таким номером уже зарегистрирован (ответ register.do/registerPreAuth.do).
+func (c ErrorCode) IsDuplicateOrder() bool {
+	return c == ErrorInvalidOrderNumber || c == ErrorDuplicateOrder
+}
+
+// Category — категория кода.
+func (c ErrorCode) Category() Category {
+	return errorDescriptions[c].category
+}
+
+// Describe — описание кода на языке lang (ru, kk, en).
+func (c ErrorCode) Describe(lang string) Description {
+	return describe(int(c), errorDescriptions[c], lang)
+}
+
+var errorDescriptions = map[ErrorCode]entry{
+	ErrorNone:               {CategorySuccess, text{"Запрос обработан без ошибок", "Сұрау қатесіз өңделді", "Request processed successfully"}, nil},
+	ErrorInvalidOrderNumber: {CategoryRequest, text{"Заказ с таким номером уже зарегистрирован или номер заказа неверен", "Осындай нөмірмен тапсырыс тіркелген немесе тапсырыс нөмірі қате", "Order number is already registered or invalid"}, nil},
+	ErrorOrderDeclined:      {CategoryDecline, text{"Заказ отклонён из-за ошибки в реквизитах платежа", "Төлем деректеріндегі қатеге байланысты тапсырыс қабылданбады", "Order declined due to invalid payment details"}, customerCheckCardData},
+	ErrorUnknownCurrency:    {CategoryRequest, text{"Неизвестная или запрещённая валюта", "Белгісіз немесе тыйым салынған валюта", "Unknown or forbidden currency"}, nil},
+	ErrorMissingParameter:   {CategoryRequest, text{"Не указан обязательный параметр запроса", "Сұраудың міндетті параметрі көрсетілмеген", "Required request parameter is missing"}, nil},
+	ErrorInvalidParameter:   {CategoryRequest, text{"Ошибка значения параметра запроса или доступ запрещён", "Сұрау параметрінің мәні қате немесе қолжетімділікке тыйым салынған", "Invalid request parameter value or access denied"}, nil},
+	ErrorOrderNotFound:      {CategoryRequest, text{"Заказ не найден", "Тапсырыс табылмады", "Order not found"}, nil},
+	ErrorSystem:             {CategoryTechnical, text{"Системная ошибка шлюза", "Шлюздың жүйелік қатесі", "Payment gateway system error"}, nil},
+	ErrorDuplicateOrder:     {CategoryRequest, text{"Заказ с таким номером уже зарегистрирован", "Осындай нөмірмен тапсырыс тіркелген", "Order with this number is already registered"}, nil},
+}
@@ -0,0 +1,101 @@
+package code
+
+// actionCodeNames — имена констант ActionCode для String().
+var actionCodeNames = map[ActionCode]string{
+	PendingReview:                 "PendingReview",
+	LimitBlock:                    "LimitBlock",
+	DSerror:                       "DSerror",
+	DSFrictionlessForbidden:       "DSFrictionlessForbidden",
+	DSAuthNotPossibleIssuer:       "DSAuthNotPossibleIssuer",
+	DSProcessingImpossible:        "DSProcessingImpossible",
+	InvalidECI:                    "InvalidECI",
+	DSAuthNotPossibleAcquirer:     "DSAuthNotPossibleAcquirer",
+	ClientDidNotReturnFromPage:    "ClientDidNotReturnFromPage",
+	TooManyPaymentAttempts:        "TooManyPaymentAttempts",
+	ClientDidNotReturnFromACS:     "ClientDidNotReturnFromACS",
+	PaymentTimeout:                "PaymentTimeout",
+	DSecureFailed:                 "DSecureFailed",
+	InvalidPaymentToken:           "InvalidPaymentToken",
+	WaitingForPaymentAttempt:      "WaitingForPaymentAttempt",
+	Success:                       "Success",
+	IdentityVerificationRequired:  "IdentityVerificationRequired",
+	CardBlockedUnknown:            "CardBlockedUnknown",
+	CardDeclinedUnknown:           "CardDeclinedUnknown",
+	OrderNotFound:                 "OrderNotFound",
+	OperationNotAllowed:           "OperationNotAllowed",
+	PermissionDenied:              "PermissionDenied",
+	InsufficientFunds:             "InsufficientFunds",
+	SecurityCall:                  "SecurityCall",
+	CardBlocked:                   "CardBlocked",
+	SecurityViolation:             "SecurityViolation",
+	CardNotAllowedForTransaction:  "CardNotAllowedForTransaction",
+	CardExpiryMismatch:            "CardExpiryMismatch",
+	InvalidAccount:                "InvalidAccount",
+	InvalidCVC:                    "InvalidCVC",
+	PreAuthTimeTooLong:            "PreAuthTimeTooLong",
+	UnknownResponseStatus:         "UnknownResponseStatus",
+	DuplicateTransmission:         "DuplicateTransmission",
+	ExceededCardLimit:             "ExceededCardLimit",
+	CardExpired:                   "CardExpired",
+	IssuerDeclined:                "IssuerDeclined",
+	TooManyPINTries:               "TooManyPINTries",
+	InvalidTerminalConfig:         "InvalidTerminalConfig",
+	InvalidPaymentAmount:          "InvalidPaymentAmount",
+	InvalidCardNumber:             "InvalidCardNumber",
+	InsufficientFundsAlt:          "InsufficientFundsAlt",
+	InvalidPIN:                    "InvalidPIN",
+	SecurityViolationAlt:          "SecurityViolationAlt",
+	IssuerOperationNotAllowed:     "IssuerOperationNotAllowed",
+	TransactionLimitExceeded:      "TransactionLimitExceeded",
+	TransactionCountLimitExceeded: "TransactionCountLimitExceeded",
+	TechnicalError:                "TechnicalError",
+	InvalidCardNumberAlt:          "InvalidCardNumberAlt",
+	FraudMonitoringDeclined:       "FraudMonitoringDeclined",
+	ServiceNotAllowed:             "ServiceNotAllowed",
+	CardLost:                      "CardLost",
+	CardLostAlt:                   "CardLostAlt",
+	CardLostRestriction:           "CardLostRestriction",
+	ParticipantBlocked:            "ParticipantBlocked",
+	RecurringPaymentStopped:       "RecurringPaymentStopped",
+	CardInternetBlocked:           "CardInternetBlocked",
+	TransactionCycleLimitExceeded: "TransactionCycleLimitExceeded",
+	CardReportedStolen:            "CardReportedStolen",
+	BalanceOrLimitExceeded:        "BalanceOrLimitExceeded",
+	InvalidMessageFormatIssuer:    "InvalidMessageFormatIssuer",
+	CardExpiredAlt:                "CardExpiredAlt",
+	IssuerUnavailable:             "IssuerUnavailable",
+	UnknownDecline:                "UnknownDecline",
+	DeclinedContactShop:           "DeclinedContactShop",
+	BankUnavailable:               "BankUnavailable",
+	InvalidMessageFormatMPS:       "InvalidMessageFormatMPS",
+	OriginalTransactionNotFound:   "OriginalTransactionNotFound",
+	UnableToProcess:               "UnableToProcess",
+	CardRestrictions:              "CardRestrictions",
+	InvalidMerchantID:             "InvalidMerchantID",
+	ReconciliationError:           "ReconciliationError",
+	SystemMalfunction:             "SystemMalfunction",
+	OriginalAmountInvalid:         "OriginalAmountInvalid",
+	ServiceUnavailable:            "ServiceUnavailable",
+	SuspectedFraud:                "SuspectedFraud",
+	TooManyRequestsSameCardExpiry: "TooManyRequestsSameCardExpiry",
+	TooManyRequestsSameCard:       "TooManyRequestsSameCard",
+	TooManyRequestsLinkedCard:     "TooManyRequestsLinkedCard",
+	TooManyFailedPayments:         "TooManyFailedPayments",
+	TooManyRequestsTerminal:       "TooManyRequestsTerminal",
+	TooManyRequestsMerchant:       "TooManyRequestsMerchant",
+	TooManyRequestsSameAmount:     "TooManyRequestsSameAmount",
+	RefundInProgress:              "RefundInProgress",
+	DSRequired:                    "DSRequired",
+	InvalidOperation:              "InvalidOperation",
+	DSRequiredForPayment:          "DSRequiredForPayment",
+	OrderAlreadyCancelled:         "OrderAlreadyCancelled",
+	DSForbidden:                   "DSForbidden",
+	ProcessingQueueLimitReached:   "ProcessingQueueLimitReached",
+	CardBlockedAlt:                "CardBlockedAlt",
+	PaymentDeclinedByMerchant:     "PaymentDeclinedByMerchant",
+	IssuerDeclinedAResA:           "IssuerDeclinedAResA",
+	DuplicateOrder:                "DuplicateOrder",
+	InvalidPaymentData:            "InvalidPaymentData",
+	ProcessingTimeoutSendFailed:   "ProcessingTimeoutSendFailed",
+	ProcessingTimeoutNoResponse:   "ProcessingTimeoutNoResponse",
+}
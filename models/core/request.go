@@ -1,6 +1,11 @@
 package core
 
-import "github.com/bsagat/bereke-merchant-api/models/types"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
 
 // ------------------------------------------------------------
 // Базовая структура заказа
@@ -29,11 +34,11 @@ type Order struct {
 	// Язык интерфейса оплаты (ISO 639-1: ru, en, by, kz, kk)
 	Language string
 
-	// Время жизни сессии оплаты в секундах
+	// Время жизни сессии оплаты в секундах (см. SetSessionTimeout)
 	SessionTimeoutSecs int
 
 	// Дата и время, когда заказ перестанет быть доступен для оплаты
-	// Формат: YYYY-MM-DDThh:mm:ss
+	// Формат: YYYY-MM-DDThh:mm:ss в часовом поясе шлюза (см. SetExpiration)
 	ExpirationDate string
 
 	// Дополнительные возможности платежа (определяется в types.PaymentFeature)
@@ -152,3 +157,219 @@ type CancelOrderRequest struct {
 	OrderNumber string // Номер заказа в системе мерчанта
 	Language    string // Язык ответа (ISO 639-1)
 }
+
+// ------------------------------------------------------------
+// Запрос на оплату через Apple Pay
+// ------------------------------------------------------------
+
+type ApplePayRequest struct {
+	// Номер заказа в системе мерчанта [1..36]
+	OrderNumber string
+
+	// Платёжный токен Apple Pay (paymentData), закодированный в base64
+	PaymentToken string
+
+	// Описание заказа
+	Description string
+
+	// Язык ответа (ISO 639-1: ru, en, by, kz, kk)
+	Language string
+
+	// true — двухстадийный платёж (средства блокируются до вызова DepositOrder)
+	PreAuth bool
+
+	// Дополнительные параметры заказа
+	AdditionalParams map[string]string
+
+	// Логин мерчанта. Если не указан — используется логин из учётных данных клиента
+	Merchant string
+}
+
+// ------------------------------------------------------------
+// Запрос на оплату через Google Pay
+// ------------------------------------------------------------
+
+type GooglePayRequest struct {
+	// Номер заказа в системе мерчанта [1..36]
+	OrderNumber string
+
+	// Платёжный токен Google Pay (paymentMethodData.tokenizationData.token), закодированный в base64
+	PaymentToken string
+
+	// Способ аутентификации карты в токене (PAN_ONLY или CRYPTOGRAM_3DS).
+	// Для PAN_ONLY обязателен ReturnURL — клиент будет перенаправлен на 3-D Secure
+	AuthMethod types.GooglePayAuthMethod
+
+	// Сумма заказа в обычных единицах валюты
+	Amount float64
+
+	// Код валюты по стандарту ISO 4217 (например, 398 — KZT)
+	Currency int
+
+	// Описание заказа
+	Description string
+
+	// Язык ответа (ISO 639-1: ru, en, by, kz, kk)
+	Language string
+
+	// true — двухстадийный платёж (средства блокируются до вызова DepositOrder)
+	PreAuth bool
+
+	// URL, на который будет перенаправлен клиент после успешной оплаты (после 3DS)
+	ReturnURL string
+
+	// URL, на который будет перенаправлен клиент при ошибке оплаты
+	FailURL string
+
+	// Информация о клиенте
+	ClientID string // Идентификатор клиента в вашей системе
+	IP       string // IP-адрес клиента
+	Email    string // Email клиента
+	Phone    string // Телефон клиента
+
+	// Дополнительные параметры заказа
+	AdditionalParams map[string]string
+
+	// Логин мерчанта. Если не указан — используется логин из учётных данных клиента
+	Merchant string
+}
+
+// ------------------------------------------------------------
+// Запрос на оплату заказа данными карты (только для PCI DSS мерчантов)
+// ------------------------------------------------------------
+
+type CardPaymentRequest struct {
+	// Идентификатор заказа в платёжном шлюзе (из RegisterOrderResponse) [1..36]
+	OrderID string
+
+	// Номер карты (PAN) [12..19 цифр]
+	PAN string
+
+	// CVC/CVV2 код [3..4 цифры]
+	CVC string
+
+	// Срок действия карты в формате YYYYMM (например, 202712)
+	Expiry string
+
+	// Имя держателя карты латиницей
+	CardholderName string
+
+	// Язык ответа (ISO 639-1: ru, en, by, kz, kk)
+	Language string
+
+	// Информация о клиенте
+	IP    string // IP-адрес клиента
+	Email string // Email клиента
+
+	// Дополнительные параметры в формате JSON
+	JSONParams string
+
+	// true — не создавать связку (не сохранять карту) по итогам оплаты
+	BindingNotNeeded bool
+}
+
+// String — маскирует карточные данные, чтобы запрос можно было безопасно выводить в лог.
+func (r CardPaymentRequest) String() string {
+	return fmt.Sprintf("CardPaymentRequest{OrderID: %s, PAN: %s, CVC: ***, Expiry: **}", r.OrderID, MaskPAN(r.PAN))
+}
+
+// GoString — аналог String для формата %#v.
+func (r CardPaymentRequest) GoString() string {
+	return r.String()
+}
+
+// MaskPAN — маскирует номер карты, оставляя первые 6 и последние 4 цифры (например, 440043******1234).
+func MaskPAN(pan string) string {
+	if len(pan) < 10 {
+		return strings.Repeat("*", len(pan))
+	}
+	return pan[:6] + strings.Repeat("*", len(pan)-10) + pan[len(pan)-4:]
+}
+
+// ------------------------------------------------------------
+// Запрос на проверку вовлечённости карты в 3-D Secure
+// ------------------------------------------------------------
+
+type EnrollmentRequest struct {
+	PAN string // Номер карты [12..19 цифр]
+}
+
+// String — маскирует номер карты.
+func (r EnrollmentRequest) String() string {
+	return fmt.Sprintf("EnrollmentRequest{PAN: %s}", MaskPAN(r.PAN))
+}
+
+// GoString — аналог String для формата %#v.
+func (r EnrollmentRequest) GoString() string {
+	return r.String()
+}
+
+// ------------------------------------------------------------
+// Запрос на продолжение оплаты по 3-D Secure 2
+// (повторный вызов paymentorder.do после 3DS Method)
+// ------------------------------------------------------------
+
+type ThreeDS2Request struct {
+	// Карточные данные заказа (те же, что и в первом вызове PayOrder)
+	CardPaymentRequest
+
+	// ID транзакции на 3DS-сервере (из ThreeDSChallenge первого ответа)
+	ThreeDSServerTransID string
+
+	// URL, на который ACS вернёт клиента после challenge
+	FinishURL string
+
+	// URL, на который эмитент отправит уведомление о завершении 3DS Method
+	MethodNotificationURL string
+
+	// Данные браузера клиента
+	Browser BrowserInfo
+}
+
+// Данные браузера клиента для оценки рисков по 3DS2
+type BrowserInfo struct {
+	AcceptHeader      string // Заголовок Accept браузера
+	UserAgent         string // Заголовок User-Agent браузера
+	Language          string // Язык браузера (navigator.language)
+	ColorDepth        int    // Глубина цвета экрана (screen.colorDepth)
+	ScreenHeight      int    // Высота экрана в пикселях
+	ScreenWidth       int    // Ширина экрана в пикселях
+	TimeZoneOffset    int    // Смещение часового пояса в минутах (Date.getTimezoneOffset)
+	JavaEnabled       bool   // navigator.javaEnabled()
+	JavaScriptEnabled bool   // true — если данные собраны через JavaScript
+	IP                string // IP-адрес клиента
+}
+
+// ------------------------------------------------------------
+// Запрос на завершение 3-D Secure 2
+// ------------------------------------------------------------
+
+type FinishThreeDSRequest struct {
+	OrderID              string // ID заказа в платёжном шлюзе
+	ThreeDSServerTransID string // ID транзакции на 3DS-сервере
+	Language             string // Язык ответа (ISO 639-1)
+}
+
+// ------------------------------------------------------------
+// Запрос на регистрацию заказа с оплатой по QR-коду
+// ------------------------------------------------------------
+
+type QRRequest struct {
+	// Данные регистрируемого заказа
+	RegisterOrderRequest
+
+	// Формат изображения QR-кода (по умолчанию PNG)
+	ImageFormat types.QRImageFormat
+
+	// Размер изображения QR-кода в пикселях (по умолчанию 256)
+	ImageSize int
+}
+
+// ------------------------------------------------------------
+// Запрос на получение статуса оплаты по QR-коду
+// ------------------------------------------------------------
+
+type QRStatusRequest struct {
+	OrderID string // ID заказа в платёжном шлюзе
+	QRID    string // ID QR-кода (из QRResponse)
+}
@@ -1,16 +1,18 @@
 package core
 
-import "github.com/bsagat/bereke-merchant-api/models/types"
+import (
+	"github.com/bsagat/bereke-merchant-api/models/code"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
 
 // ------------------------------------------------------------
 // Базовый ответ API
 // ------------------------------------------------------------
 
 type Response struct {
-	// Код ошибки:
-	// 0  — успех
-	// 1-99 — ошибка
-	ErrorCode int
+	// Код ошибки обработки запроса (errorCode):
+	// code.ErrorNone — успех, остальные значения — ошибка
+	ErrorCode code.ErrorCode
 
 	// Человеко-читаемое описание ошибки
 	// Язык текста зависит от параметров запроса
@@ -45,16 +47,16 @@ type OrderStatusResponse struct {
 	OrderStatus types.OrderStatus // Статус заказа (enum)
 
 	// --- Ответ банка ---
-	ActionCode            int    // Код ответа банка
-	ActionCodeDescription string // Текстовое описание кода
-	AuthRefNum            string // Номер авторизации
-	TerminalID            string // ID терминала банка
+	ActionCode            code.ActionCode // Код ответа банка
+	ActionCodeDescription string          // Текстовое описание кода
+	AuthRefNum            string          // Номер авторизации
+	TerminalID            string          // ID терминала банка
 
 	// --- Финансовая информация ---
 	Amount   float64 // Сумма заказа в основных единицах валюты
 	Currency int     // Код валюты (ISO 4217)
 
-	// --- Временные метки (Unix ms) ---
+	// --- Временные метки (Unix ms; как time.Time — CreatedAt, DepositedAt и т.д.) ---
 	Date          int64 // Дата создания заказа
 	DepositedDate int64 // Дата депозита
 	RefundedDate  int64 // Дата возврата
@@ -96,7 +98,8 @@ type PaymentAmountInfo struct {
 // Информация о банке
 type BankInfo struct {
 	BankName        string // Название банка (до 50 символов)
-	BankCountryCode int    // Код страны банка (до 4 символов)
+	BankCountryCode int    // Числовой код страны банка (ISO 3166-1); 0 — код не числовой, см. BankCountry
+	BankCountry     string // Код страны банка, как его вернул шлюз (например, "398" или "KZ")
 	BankCountryName string // Название страны банка (до 160 символов)
 }
 
@@ -108,3 +111,86 @@ type CardInfo struct {
 	Pan            string // Полный номер карты (до 19 символов)
 	ApprovalCode   string // Код авторизации (до 6 символов)
 }
+
+// ------------------------------------------------------------
+// Ответ на оплату заказа (Apple Pay, Google Pay, оплата картой)
+// ------------------------------------------------------------
+
+type PaymentResponse struct {
+	Response
+
+	// Идентификатор заказа в платёжном шлюзе
+	OrderID string
+
+	// URL, на который нужно перенаправить клиента после оплаты (если вернул шлюз)
+	Redirect string
+
+	// Код ответа процессинга по операции (0 — шлюз его не вернул)
+	ActionCode code.ActionCode
+
+	// Данные для прохождения 3-D Secure.
+	// nil — если аутентификация клиента не требуется
+	ThreeDS *ThreeDSChallenge
+}
+
+// Данные для перенаправления клиента на ACS банка-эмитента (3-D Secure)
+type ThreeDSChallenge struct {
+	ACSUrl  string // URL страницы ACS банка-эмитента
+	PaReq   string // Запрос на аутентификацию (3DS1), передаётся в ACS
+	TermURL string // URL, на который ACS вернёт клиента после аутентификации (3DS1)
+	MD      string // Данные мерчанта (ID заказа), передаются в ACS вместе с PaReq (3DS1)
+
+	// --- 3-D Secure 2 ---
+	Is3DSVer2            bool   // true — аутентификация по протоколу 3DS2
+	ThreeDSServerTransID string // ID транзакции на 3DS-сервере
+	ThreeDSMethodURL     string // URL 3DS Method эмитента (загружается в скрытом iframe)
+	ThreeDSMethodData    string // Упакованные данные для 3DS Method
+	PackedCReq           string // Упакованный CReq, передаётся в ACS при challenge
+}
+
+// ------------------------------------------------------------
+// Ответ на проверку вовлечённости карты в 3-D Secure
+// ------------------------------------------------------------
+
+type EnrollmentResponse struct {
+	Response
+
+	// Вовлечённость карты в 3DS: Y — вовлечена, N — не вовлечена, U — неизвестно
+	Enrolled string
+
+	EmitterName        string // Название банка-эмитента
+	EmitterCountryCode string // Код страны банка-эмитента
+}
+
+// IsEnrolled — true, если карта вовлечена в 3-D Secure.
+func (r EnrollmentResponse) IsEnrolled() bool {
+	return r.Enrolled == "Y"
+}
+
+// ------------------------------------------------------------
+// Ответ при регистрации заказа с оплатой по QR-коду
+// ------------------------------------------------------------
+
+type QRResponse struct {
+	Response
+
+	OrderID string // ID заказа в платёжном шлюзе
+	QRID    string // ID QR-кода
+	Payload string // Содержимое QR-кода (ссылка на оплату)
+
+	// Изображение QR-кода, сформированное локально из Payload
+	Image       []byte
+	ImageFormat types.QRImageFormat
+}
+
+// ------------------------------------------------------------
+// Ответ со статусом оплаты по QR-коду
+// ------------------------------------------------------------
+
+type QRStatusResponse struct {
+	Response
+
+	QRStatus         types.QRStatus    // Статус QR-кода
+	OrderStatus      types.OrderStatus // Статус заказа, соответствующий статусу QR-кода
+	TransactionState string            // Состояние транзакции в шлюзе
+}
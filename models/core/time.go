@@ -0,0 +1,72 @@
+package core
+
+import (
+	"time"
+)
+
+// GatewayLocation — часовой пояс платёжного шлюза (Asia/Almaty).
+// Если база часовых поясов недоступна, используется фиксированное смещение UTC+5.
+var GatewayLocation = loadGatewayLocation()
+
+func loadGatewayLocation() *time.Location {
+	if loc, err := time.LoadLocation("Asia/Almaty"); err == nil {
+		return loc
+	}
+	return time.FixedZone("Asia/Almaty", 5*60*60)
+}
+
+// SetExpiration — установка ExpirationDate по моменту времени.
+// Время переводится в часовой пояс шлюза и форматируется как YYYY-MM-DDThh:mm:ss.
+// Нулевое время очищает поле.
+func (o *Order) SetExpiration(t time.Time) {
+	if t.IsZero() {
+		o.ExpirationDate = ""
+		return
+	}
+	o.ExpirationDate = t.In(GatewayLocation).Format(ExpirationDateLayout)
+}
+
+// Expiration — ExpirationDate как момент времени (в часовом поясе шлюза).
+// Для пустого поля возвращает нулевое время без ошибки.
+func (o Order) Expiration() (time.Time, error) {
+	if o.ExpirationDate == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(ExpirationDateLayout, o.ExpirationDate, GatewayLocation)
+}
+
+// SetSessionTimeout — установка SessionTimeoutSecs по длительности (с округлением вверх до секунды).
+func (o *Order) SetSessionTimeout(d time.Duration) {
+	o.SessionTimeoutSecs = int((d + time.Second - 1) / time.Second)
+}
+
+// SessionTimeout — SessionTimeoutSecs как длительность.
+func (o Order) SessionTimeout() time.Duration {
+	return time.Duration(o.SessionTimeoutSecs) * time.Second
+}
+
+// unixMilli — перевод Unix-времени в миллисекундах в time.Time (0 — нулевое время).
+func unixMilli(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).In(GatewayLocation)
+}
+
+// CreatedAt — дата создания заказа (Date).
+func (r OrderStatusResponse) CreatedAt() time.Time { return unixMilli(r.Date) }
+
+// DepositedAt — дата списания средств (DepositedDate).
+func (r OrderStatusResponse) DepositedAt() time.Time { return unixMilli(r.DepositedDate) }
+
+// RefundedAt — дата возврата (RefundedDate).
+func (r OrderStatusResponse) RefundedAt() time.Time { return unixMilli(r.RefundedDate) }
+
+// ReversedAt — дата сторнирования (ReversedDate).
+func (r OrderStatusResponse) ReversedAt() time.Time { return unixMilli(r.ReversedDate) }
+
+// AuthorizedAt — дата авторизации (AuthDateTime).
+func (r OrderStatusResponse) AuthorizedAt() time.Time { return unixMilli(r.AuthDateTime) }
+
+// AuthorizedAt — дата авторизации по привязке (AuthDateTime).
+func (b BindingInfo) AuthorizedAt() time.Time { return unixMilli(b.AuthDateTime) }
@@ -0,0 +1,111 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetExpiration(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{"utc", time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC), "2025-06-01T15:00:00"},
+		{"other zone", time.Date(2025, 6, 1, 12, 30, 15, 0, time.FixedZone("MSK", 3*60*60)), "2025-06-01T14:30:15"},
+		{"next day in almaty", time.Date(2025, 12, 31, 21, 0, 0, 0, time.UTC), "2026-01-01T02:00:00"},
+		{"sub-second", time.Date(2025, 6, 1, 10, 0, 0, 999_000_000, time.UTC), "2025-06-01T15:00:00"},
+		{"zero", time.Time{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := Order{ExpirationDate: "2000-01-01T00:00:00"}
+			o.SetExpiration(tt.t)
+			if o.ExpirationDate != tt.want {
+				t.Fatalf("ExpirationDate = %q, want %q", o.ExpirationDate, tt.want)
+			}
+
+			got, err := o.Expiration()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := tt.t.Truncate(time.Second); !got.Equal(want) {
+				t.Errorf("Expiration() = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestSetSessionTimeout(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want int
+	}{
+		{0, 0},
+		{time.Millisecond, 1},
+		{time.Second, 1},
+		{1500 * time.Millisecond, 2},
+		{20 * time.Minute, 1200},
+	}
+
+	for _, tt := range tests {
+		var o Order
+		o.SetSessionTimeout(tt.d)
+		if o.SessionTimeoutSecs != tt.want {
+			t.Errorf("SetSessionTimeout(%s): SessionTimeoutSecs = %d, want %d", tt.d, o.SessionTimeoutSecs, tt.want)
+		}
+		if got := o.SessionTimeout(); got != time.Duration(tt.want)*time.Second {
+			t.Errorf("SetSessionTimeout(%s): SessionTimeout() = %s", tt.d, got)
+		}
+	}
+}
+
+func TestStatusTimestamps(t *testing.T) {
+	created := time.Date(2025, 6, 1, 10, 0, 0, 123_000_000, time.UTC)
+	deposited := created.Add(90 * time.Second)
+
+	tests := []struct {
+		name          string
+		resp          OrderStatusResponse
+		wantCreated   time.Time
+		wantDeposited time.Time
+	}{
+		{
+			name:          "milliseconds",
+			resp:          OrderStatusResponse{Date: created.UnixMilli(), DepositedDate: deposited.UnixMilli()},
+			wantCreated:   created,
+			wantDeposited: deposited,
+		},
+		{
+			name:        "not deposited",
+			resp:        OrderStatusResponse{Date: created.UnixMilli()},
+			wantCreated: created,
+		},
+		{name: "absent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTime(t, "CreatedAt", tt.resp.CreatedAt(), tt.wantCreated)
+			checkTime(t, "DepositedAt", tt.resp.DepositedAt(), tt.wantDeposited)
+		})
+	}
+}
+
+// checkTime — сравнение момента времени; ненулевое время должно быть в часовом поясе шлюза.
+func checkTime(t *testing.T, name string, got, want time.Time) {
+	t.Helper()
+	if want.IsZero() {
+		if !got.IsZero() {
+			t.Errorf("%s = %s, want zero time", name, got)
+		}
+		return
+	}
+	if !got.Equal(want) {
+		t.Errorf("%s = %s, want %s", name, got, want)
+	}
+	if got.Location() != GatewayLocation {
+		t.Errorf("%s location = %s, want %s", name, got.Location(), GatewayLocation)
+	}
+}
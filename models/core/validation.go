@@ -0,0 +1,127 @@
+package core
+
+import (
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Ограничения полей заказа (register.do / registerPreAuth.do).
+const (
+	MaxOrderNumberLength = 36  // Максимальная длина OrderNumber
+	MaxDescriptionLength = 598 // Максимальная длина Description
+	MaxURLLength         = 512 // Максимальная длина ReturnURL/FailURL
+
+	// Формат ExpirationDate
+	ExpirationDateLayout = "2006-01-02T15:04:05"
+)
+
+// SupportedLanguages — языки платёжной страницы (ISO 639-1).
+var SupportedLanguages = []string{"ru", "en", "by", "kz", "kk"}
+
+// FieldError — ошибка проверки одного поля.
+type FieldError struct {
+	Field   string // Имя поля структуры (например, "ReturnURL")
+	Message string // Описание нарушения
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError — ошибка локальной проверки запроса со списком всех нарушений.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+// err — nil, если нарушений нет.
+func (e *ValidationError) err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Validate — локальная проверка заказа перед отправкой в шлюз.
+// Возвращает *ValidationError со всеми найденными нарушениями или nil.
+func (o Order) Validate() error {
+	var v ValidationError
+
+	switch n := utf8.RuneCountInString(o.OrderNumber); {
+	case n == 0:
+		v.add("OrderNumber", "is required")
+	case n > MaxOrderNumberLength:
+		v.add("OrderNumber", "must be at most 36 characters")
+	}
+
+	if o.Amount < 0 {
+		v.add("Amount", "must not be negative")
+	}
+	if o.FeeInput < 0 {
+		v.add("FeeInput", "must not be negative")
+	}
+	if o.SessionTimeoutSecs < 0 {
+		v.add("SessionTimeoutSecs", "must not be negative")
+	}
+
+	if o.ReturnURL == "" {
+		v.add("ReturnURL", "is required")
+	} else if msg := checkURL(o.ReturnURL); msg != "" {
+		v.add("ReturnURL", msg)
+	}
+	if o.FailURL != "" {
+		if msg := checkURL(o.FailURL); msg != "" {
+			v.add("FailURL", msg)
+		}
+	}
+
+	if utf8.RuneCountInString(o.Description) > MaxDescriptionLength {
+		v.add("Description", "must be at most 598 characters")
+	}
+
+	if o.Language != "" && !isSupportedLanguage(o.Language) {
+		v.add("Language", "must be one of "+strings.Join(SupportedLanguages, ", "))
+	}
+
+	if o.ExpirationDate != "" {
+		if _, err := time.Parse(ExpirationDateLayout, o.ExpirationDate); err != nil {
+			v.add("ExpirationDate", "must match YYYY-MM-DDThh:mm:ss")
+		}
+	}
+
+	return v.err()
+}
+
+// checkURL — проверка абсолютного http(s)-URL; возвращает описание нарушения или "".
+func checkURL(raw string) string {
+	if len(raw) > MaxURLLength {
+		return "must be at most 512 characters"
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "must be an absolute http(s) URL"
+	}
+	return ""
+}
+
+func isSupportedLanguage(lang string) bool {
+	for _, l := range SupportedLanguages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
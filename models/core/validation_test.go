@@ -0,0 +1,83 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func validOrder() Order {
+	return Order{
+		OrderNumber: "A-100500",
+		Amount:      1500,
+		Currency:    398,
+		ReturnURL:   "https://shop.example.com/ok",
+	}
+}
+
+func TestOrderValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(o *Order)
+		fields []string
+	}{
+		{"valid", func(o *Order) {}, nil},
+		{"max lengths", func(o *Order) {
+			o.OrderNumber = strings.Repeat("я", MaxOrderNumberLength)
+			o.Description = strings.Repeat("я", MaxDescriptionLength)
+			o.ReturnURL = "https://shop.example.com/" + strings.Repeat("a", MaxURLLength-25)
+			o.Language = "kk"
+			o.ExpirationDate = "2026-10-19T12:00:00"
+		}, nil},
+		{"order number required", func(o *Order) { o.OrderNumber = "" }, []string{"OrderNumber"}},
+		{"order number too long", func(o *Order) { o.OrderNumber = strings.Repeat("1", MaxOrderNumberLength+1) }, []string{"OrderNumber"}},
+		{"description too long", func(o *Order) { o.Description = strings.Repeat("я", MaxDescriptionLength+1) }, []string{"Description"}},
+		{"return url required", func(o *Order) { o.ReturnURL = "" }, []string{"ReturnURL"}},
+		{"return url relative", func(o *Order) { o.ReturnURL = "/ok" }, []string{"ReturnURL"}},
+		{"return url scheme", func(o *Order) { o.ReturnURL = "javascript:alert(1)" }, []string{"ReturnURL"}},
+		{"return url too long", func(o *Order) { o.ReturnURL = "https://shop.example.com/" + strings.Repeat("a", MaxURLLength) }, []string{"ReturnURL"}},
+		{"fail url", func(o *Order) { o.FailURL = "ftp://shop.example.com" }, []string{"FailURL"}},
+		{"language", func(o *Order) { o.Language = "de" }, []string{"Language"}},
+		{"expiration format", func(o *Order) { o.ExpirationDate = "19.10.2026 12:00" }, []string{"ExpirationDate"}},
+		{"all fields at once", func(o *Order) {
+			o.OrderNumber = ""
+			o.Amount = -1
+			o.FeeInput = -1
+			o.SessionTimeoutSecs = -1
+			o.ReturnURL = ""
+			o.Language = "de"
+		}, []string{"OrderNumber", "Amount", "FeeInput", "SessionTimeoutSecs", "ReturnURL", "Language"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := validOrder()
+			tt.modify(&order)
+			err := order.Validate()
+
+			if tt.fields == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("err = %v, want *ValidationError", err)
+			}
+			var got []string
+			for _, f := range verr.Fields {
+				got = append(got, f.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.fields, ",") {
+				t.Errorf("fields = %v, want %v", got, tt.fields)
+			}
+			for _, field := range tt.fields {
+				if !strings.Contains(err.Error(), field+": ") {
+					t.Errorf("error %q does not mention %s", err, field)
+				}
+			}
+		})
+	}
+}
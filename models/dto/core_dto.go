@@ -83,3 +83,85 @@ func FromCoreCancelOrder(req core.CancelOrderRequest) CancelOrderRequest {
 		Language:    req.Language,
 	}
 }
+
+func FromCoreApplePay(req core.ApplePayRequest) ApplePayRequest {
+	return ApplePayRequest{
+		Merchant:             req.Merchant,
+		OrderNumber:          req.OrderNumber,
+		Description:          req.Description,
+		Language:             req.Language,
+		AdditionalParameters: req.AdditionalParams,
+		PreAuth:              req.PreAuth,
+		PaymentToken:         req.PaymentToken,
+	}
+}
+
+func FromCoreGooglePay(req core.GooglePayRequest) GooglePayRequest {
+	return GooglePayRequest{
+		Merchant:             req.Merchant,
+		OrderNumber:          req.OrderNumber,
+		Description:          req.Description,
+		Language:             req.Language,
+		AdditionalParameters: req.AdditionalParams,
+		PreAuth:              req.PreAuth,
+		ClientID:             req.ClientID,
+		PaymentToken:         req.PaymentToken,
+		AuthMethod:           string(req.AuthMethod),
+		IP:                   req.IP,
+		Amount:               money.ToMinorUnit(req.Amount, req.Currency),
+		CurrencyCode:         req.Currency,
+		Email:                req.Email,
+		Phone:                req.Phone,
+		ReturnURL:            req.ReturnURL,
+		FailURL:              req.FailURL,
+	}
+}
+
+// FromCoreCardPayment — срок действия (YYYYMM) должен быть проверен заранее.
+func FromCoreCardPayment(req core.CardPaymentRequest) CardPaymentRequest {
+	var year, month string
+	if len(req.Expiry) == 6 {
+		year, month = req.Expiry[:4], req.Expiry[4:]
+	}
+
+	return CardPaymentRequest{
+		OrderID:          req.OrderID,
+		PAN:              req.PAN,
+		CVC:              req.CVC,
+		Year:             year,
+		Month:            month,
+		CardholderName:   req.CardholderName,
+		Language:         req.Language,
+		IP:               req.IP,
+		Email:            req.Email,
+		JSONParams:       req.JSONParams,
+		BindingNotNeeded: req.BindingNotNeeded,
+	}
+}
+
+func FromCoreThreeDS2(req core.ThreeDS2Request) ThreeDS2Request {
+	return ThreeDS2Request{
+		CardPaymentRequest:    FromCoreCardPayment(req.CardPaymentRequest),
+		ThreeDSServerTransID:  req.ThreeDSServerTransID,
+		FinishURL:             req.FinishURL,
+		MethodNotificationURL: req.MethodNotificationURL,
+
+		BrowserAcceptHeader: req.Browser.AcceptHeader,
+		BrowserUserAgent:    req.Browser.UserAgent,
+		BrowserLanguage:     req.Browser.Language,
+		BrowserColorDepth:   req.Browser.ColorDepth,
+		BrowserScreenHeight: req.Browser.ScreenHeight,
+		BrowserScreenWidth:  req.Browser.ScreenWidth,
+		BrowserTZ:           req.Browser.TimeZoneOffset,
+		BrowserJavaEnabled:  req.Browser.JavaEnabled,
+		BrowserJsEnabled:    req.Browser.JavaScriptEnabled,
+		BrowserIPAddress:    req.Browser.IP,
+	}
+}
+
+func FromCoreFinishThreeDS(req core.FinishThreeDSRequest) FinishThreeDSRequest {
+	return FinishThreeDSRequest{
+		TransID:  req.ThreeDSServerTransID,
+		Language: req.Language,
+	}
+}
@@ -0,0 +1,231 @@
+package dto
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bsagat/bereke-merchant-api/models/code"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+// load — декодирование записанного ответа шлюза из testdata.
+func load(t *testing.T, name string, v interface{}) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("decode %s: %v", name, err)
+	}
+}
+
+func TestDecodeOrderStatus(t *testing.T) {
+	tests := []struct {
+		file string
+		want core.OrderStatusResponse
+	}{
+		{
+			file: "getOrderStatusExtended.json",
+			want: core.OrderStatusResponse{
+				Response:          core.Response{ErrorMessage: "Успешно"},
+				Amount:            1500,
+				Currency:          398,
+				OrderNumber:       "A-100500",
+				OrderStatus:       types.OrderStatusCompleted,
+				ActionCode:        code.Success,
+				AuthRefNum:        "111111111111",
+				TerminalID:        "90000001",
+				Date:              1760860800123,
+				DepositedDate:     1760860865000,
+				AuthDateTime:      1760860865000,
+				BindingInfo:       core.BindingInfo{ClientID: "client-1", BindingID: "binding-1"},
+				PaymentAmountInfo: core.PaymentAmountInfo{ApprovedAmount: 150000, DepositedAmount: 150000, PaymentState: "DEPOSITED"},
+				BankInfo:          core.BankInfo{BankName: "BEREKE BANK", BankCountry: "KZ", BankCountryName: "Казахстан"},
+				CardInfo:          core.CardInfo{MaskedPan: "440043**0000", Expiration: "202812", CardholderName: "IVAN IVANOV", ApprovalCode: "123456"},
+				PaymentWay:        "CARD",
+			},
+		},
+		{
+			// Числа строками и наоборот; orderStatus отсутствует — статус по paymentState
+			file: "getOrderStatusExtended_no_status.json",
+			want: core.OrderStatusResponse{
+				Amount:                2500,
+				Currency:              398,
+				OrderNumber:           "A-100501",
+				OrderStatus:           types.OrderStatusRefunded,
+				ActionCode:            code.PaymentTimeout,
+				ActionCodeDescription: "Время сессии истекло",
+				Date:                  1760860800123,
+				PaymentAmountInfo:     core.PaymentAmountInfo{ApprovedAmount: 250000, DepositedAmount: 250000, RefundedAmount: 250000, PaymentState: "REFUNDED"},
+				BankInfo:              core.BankInfo{BankName: "TEST BANK", BankCountryCode: 398, BankCountry: "398", BankCountryName: "Казахстан"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			var res OrderStatusResponse
+			load(t, tt.file, &res)
+			if got := res.DtoToCore(); got != tt.want {
+				t.Errorf("DtoToCore:\n got  %+v\n want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeRegisterOrder(t *testing.T) {
+	tests := []struct {
+		file string
+		want core.RegisterOrderResponse
+	}{
+		{
+			file: "register.json",
+			want: core.RegisterOrderResponse{
+				OrderID: "0f1f2e3d-4c5b-7a69-8877-665544332211",
+				FormURL: "https://3dsec.berekebank.kz/payment/merchants/test/payment_ru.html?mdOrder=0f1f2e3d-4c5b-7a69-8877-665544332211",
+			},
+		},
+		{
+			file: "register_duplicate.json",
+			want: core.RegisterOrderResponse{
+				Response: core.Response{ErrorCode: 1, ErrorMessage: "Заказ с таким номером уже обработан"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			var res RegisterOrderResponse
+			load(t, tt.file, &res)
+			if got := res.DtoToCore(); got != tt.want {
+				t.Errorf("DtoToCore:\n got  %+v\n want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeWalletPayment(t *testing.T) {
+	tests := []struct {
+		file    string
+		want    core.Response
+		orderID string
+		threeDS *core.ThreeDSChallenge
+	}{
+		{
+			file:    "applepay_success.json",
+			orderID: "6c4f8a2e-9b1d-7e53-a0c2-1f3e5d7b9a11",
+		},
+		{
+			// Код ошибки числом
+			file: "applepay_error.json",
+			want: core.Response{ErrorCode: 5, ErrorMessage: "Доступ запрещён"},
+		},
+		{
+			file:    "googlepay_3ds.json",
+			orderID: "7d5a9b3f-0c2e-7f64-b1d3-2a4f6e8c0b22",
+			threeDS: &core.ThreeDSChallenge{
+				ACSUrl:  "https://acs.example.com/pareq",
+				PaReq:   "eJxVUk1vgzAMvfMrEPcR",
+				TermURL: "https://3dsec.berekebank.kz/payment/rest/finish3ds.do",
+			},
+		},
+		{
+			// Код ошибки строкой, сообщение только в description
+			file: "googlepay_error.json",
+			want: core.Response{ErrorCode: 10, ErrorMessage: "Неверный платёжный токен"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			var res WalletPaymentResponse
+			load(t, tt.file, &res)
+			got := res.DtoToCore()
+
+			if got.Response != tt.want {
+				t.Errorf("Response = %+v, want %+v", got.Response, tt.want)
+			}
+			if got.OrderID != tt.orderID {
+				t.Errorf("OrderID = %q, want %q", got.OrderID, tt.orderID)
+			}
+			switch {
+			case tt.threeDS == nil && got.ThreeDS != nil:
+				t.Errorf("ThreeDS = %+v, want nil", got.ThreeDS)
+			case tt.threeDS != nil && (got.ThreeDS == nil || *got.ThreeDS != *tt.threeDS):
+				t.Errorf("ThreeDS = %+v, want %+v", got.ThreeDS, tt.threeDS)
+			}
+		})
+	}
+}
+
+func TestDecodeErrorCode(t *testing.T) {
+	tests := []struct {
+		body string
+		want code.ErrorCode
+	}{
+		{`{"errorCode":0}`, 0},
+		{`{"errorCode":"0"}`, 0},
+		{`{"errorCode":5}`, 5},
+		{`{"errorCode":"5"}`, 5},
+		{`{"errorCode":""}`, 0},
+		{`{"errorCode":null}`, 0},
+		{`{}`, 0},
+	}
+
+	for _, tt := range tests {
+		var res Response
+		if err := json.Unmarshal([]byte(tt.body), &res); err != nil {
+			t.Errorf("%s: %v", tt.body, err)
+			continue
+		}
+		if got := res.GatewayErrorCode(); got != tt.want {
+			t.Errorf("%s: errorCode = %d, want %d", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestBankInfoCountryCode(t *testing.T) {
+	tests := []struct {
+		body string
+		want core.BankInfo
+	}{
+		{`{"bankCountryCode":"398"}`, core.BankInfo{BankCountryCode: 398, BankCountry: "398"}},
+		{`{"bankCountryCode":398}`, core.BankInfo{BankCountryCode: 398, BankCountry: "398"}},
+		{`{"bankCountryCode":"KZ"}`, core.BankInfo{BankCountry: "KZ"}},
+		{`{"bankCountryCode":"UNKNOWN"}`, core.BankInfo{BankCountry: "UNKNOWN"}},
+		{`{}`, core.BankInfo{}},
+	}
+
+	for _, tt := range tests {
+		var res BankInfo
+		if err := json.Unmarshal([]byte(tt.body), &res); err != nil {
+			t.Errorf("%s: %v", tt.body, err)
+			continue
+		}
+		if got := res.DtoToCore(); got != tt.want {
+			t.Errorf("%s: BankInfo = %+v, want %+v", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestDecodePaymentActionCode(t *testing.T) {
+	var card CardPaymentResponse
+	if err := json.Unmarshal([]byte(`{"errorCode":"0","actionCode":"912","info":"Эмитент недоступен"}`), &card); err != nil {
+		t.Fatal(err)
+	}
+	if got := card.DtoToCore("order-1").ActionCode; got != code.BankUnavailable {
+		t.Errorf("card actionCode = %d, want %d", got, code.BankUnavailable)
+	}
+
+	var wallet WalletPaymentResponse
+	if err := json.Unmarshal([]byte(`{"success":false,"actionCode":907,"error":{"code":"5"}}`), &wallet); err != nil {
+		t.Fatal(err)
+	}
+	if got := wallet.DtoToCore().ActionCode; got != code.IssuerUnavailable {
+		t.Errorf("wallet actionCode = %d, want %d", got, code.IssuerUnavailable)
+	}
+}
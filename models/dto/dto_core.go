@@ -2,15 +2,17 @@ package dto
 
 import (
 	"strconv"
+	"strings"
 
 	money "github.com/bsagat/bereke-merchant-api/currency"
+	"github.com/bsagat/bereke-merchant-api/models/code"
 	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/types"
 )
 
 func (res *Response) DtoToCore() core.Response {
-	convertedCode, _ := strconv.Atoi(res.ErrorCode)
 	return core.Response{
-		ErrorCode:    convertedCode,
+		ErrorCode:    res.GatewayErrorCode(),
 		ErrorMessage: res.ErrorMessage,
 	}
 }
@@ -24,28 +26,28 @@ func (res *RegisterOrderResponse) DtoToCore() core.RegisterOrderResponse {
 }
 
 func (res *OrderStatusResponse) DtoToCore() core.OrderStatusResponse {
-	convertedCurrency := money.ToNumeric(res.Currency)
+	convertedCurrency := currencyCode(string(res.Currency))
 
 	return core.OrderStatusResponse{
 		Response: res.Response.DtoToCore(),
-		Amount:   money.ConvertFromMinorUnits(res.MinorAmount, convertedCurrency),
+		Amount:   money.ConvertFromMinorUnits(int(res.MinorAmount), convertedCurrency),
 		Currency: convertedCurrency,
 
 		OrderID:     res.OrderID,
 		OrderNumber: res.OrderNumber,
-		OrderStatus: res.OrderStatus,
+		OrderStatus: res.orderStatus(),
 
-		ActionCode:            res.ActionCode,
+		ActionCode:            code.ActionCode(res.ActionCode),
 		ActionCodeDescription: res.ActionCodeDescription,
 
 		AuthRefNum: res.AuthRefNum,
 		TerminalID: res.TerminalID,
 
-		Date:          res.Date,
-		DepositedDate: res.DepositedDate,
-		RefundedDate:  res.RefundedDate,
-		ReversedDate:  res.ReversedDate,
-		AuthDateTime:  res.AuthDateTime,
+		Date:          int64(res.Date),
+		DepositedDate: int64(res.DepositedDate),
+		RefundedDate:  int64(res.RefundedDate),
+		ReversedDate:  int64(res.ReversedDate),
+		AuthDateTime:  int64(res.AuthDateTime),
 
 		BindingInfo:       res.BindingInfo.DtoToCore(),
 		PaymentAmountInfo: res.PaymentAmountInfo.DtoToCore(),
@@ -57,11 +59,42 @@ func (res *OrderStatusResponse) DtoToCore() core.OrderStatusResponse {
 	}
 }
 
+// orderStatus — статус заказа. Если orderStatus отсутствует в ответе,
+// статус определяется по paymentAmountInfo.paymentState.
+func (res *OrderStatusResponse) orderStatus() types.OrderStatus {
+	if res.OrderStatus != nil {
+		return types.OrderStatus(*res.OrderStatus)
+	}
+
+	switch types.PaymentState(res.PaymentAmountInfo.PaymentState) {
+	case types.OrderApproved:
+		return types.OrderStatusAuthorized
+	case types.OrderDeposited:
+		return types.OrderStatusCompleted
+	case types.OrderDeclined:
+		return types.OrderStatusDeclined
+	case types.OrderReversed:
+		return types.OrderStatusCancelled
+	case types.OrderRefunded:
+		return types.OrderStatusRefunded
+	default:
+		return types.OrderStatusRegistered
+	}
+}
+
+// currencyCode — числовой код валюты из числового ("398") или буквенного ("KZT") значения.
+func currencyCode(value string) int {
+	if n, err := strconv.Atoi(value); err == nil {
+		return n
+	}
+	return money.ToNumeric(value)
+}
+
 func (res *BindingInfo) DtoToCore() core.BindingInfo {
 	return core.BindingInfo{
 		ClientID:     res.ClientID,
 		BindingID:    res.BindingID,
-		AuthDateTime: res.AuthDateTime,
+		AuthDateTime: int64(res.AuthDateTime),
 		AuthRefNum:   res.AuthRefNum,
 		TerminalID:   res.TerminalID,
 	}
@@ -69,18 +102,25 @@ func (res *BindingInfo) DtoToCore() core.BindingInfo {
 
 func (res *PaymentAmountInfo) DtoToCore() core.PaymentAmountInfo {
 	return core.PaymentAmountInfo{
-		ApprovedAmount:  res.ApprovedAmount,
-		DepositedAmount: res.DepositedAmount,
-		RefundedAmount:  res.RefundedAmount,
+		ApprovedAmount:  int64(res.ApprovedAmount),
+		DepositedAmount: int64(res.DepositedAmount),
+		RefundedAmount:  int64(res.RefundedAmount),
 		PaymentState:    res.PaymentState,
 	}
 }
 
+// DtoToCore — данные о банке. Шлюз возвращает код страны числом ("398") или буквами ("KZ"):
+// исходное значение сохраняется в BankCountry, BankCountryCode заполняется только для числового кода.
 func (res *BankInfo) DtoToCore() core.BankInfo {
-	countryCode, _ := strconv.Atoi(res.BankCountryCode)
+	country := strings.TrimSpace(string(res.BankCountryCode))
+	countryCode, err := strconv.Atoi(country)
+	if err != nil {
+		countryCode = 0
+	}
 	return core.BankInfo{
 		BankName:        res.BankName,
 		BankCountryCode: countryCode,
+		BankCountry:     country,
 		BankCountryName: res.BankCountryName,
 	}
 }
@@ -94,3 +134,118 @@ func (res *CardInfo) DtoToCore() core.CardInfo {
 		ApprovalCode:   res.ApprovalCode,
 	}
 }
+
+func (res *WalletPaymentResponse) DtoToCore() core.PaymentResponse {
+	message := res.Error.Message
+	if message == "" {
+		message = res.Error.Description
+	}
+
+	response := core.PaymentResponse{
+		Response: core.Response{
+			ErrorCode:    res.GatewayErrorCode(),
+			ErrorMessage: message,
+		},
+		OrderID:    res.Data.OrderID,
+		ActionCode: res.GatewayActionCode(),
+	}
+
+	if res.Data.ACSUrl != "" {
+		response.ThreeDS = &core.ThreeDSChallenge{
+			ACSUrl:  res.Data.ACSUrl,
+			PaReq:   res.Data.PaReq,
+			TermURL: res.Data.TermURL,
+		}
+	}
+	return response
+}
+
+func (res *CardPaymentResponse) DtoToCore(orderID string) core.PaymentResponse {
+	response := core.PaymentResponse{
+		Response:   res.Response.DtoToCore(),
+		OrderID:    orderID,
+		Redirect:   res.Redirect,
+		ActionCode: res.GatewayActionCode(),
+	}
+
+	if res.ACSUrl != "" || res.ThreeDSMethodURL != "" || res.ThreeDSServerTransID != "" {
+		response.ThreeDS = &core.ThreeDSChallenge{
+			ACSUrl:  res.ACSUrl,
+			PaReq:   res.PaReq,
+			TermURL: res.TermURL,
+			MD:      orderID,
+
+			Is3DSVer2:            res.Is3DSVer2,
+			ThreeDSServerTransID: res.ThreeDSServerTransID,
+			ThreeDSMethodURL:     res.ThreeDSMethodURL,
+			ThreeDSMethodData:    res.ThreeDSMethodDataPacked,
+			PackedCReq:           res.PackedCReq,
+		}
+	}
+	return response
+}
+
+func (res *EnrollmentResponse) DtoToCore() core.EnrollmentResponse {
+	return core.EnrollmentResponse{
+		Response:           res.Response.DtoToCore(),
+		Enrolled:           res.Enrolled,
+		EmitterName:        res.EmitterName,
+		EmitterCountryCode: res.EmitterCountryCode,
+	}
+}
+
+func (res *QRStatusResponse) DtoToCore() core.QRStatusResponse {
+	status := types.QRStatus(res.QRStatus)
+	return core.QRStatusResponse{
+		Response:         res.Response.DtoToCore(),
+		QRStatus:         status,
+		OrderStatus:      status.OrderStatus(),
+		TransactionState: res.TransactionState,
+	}
+}
+
+// GatewayErrorCode — числовой код ошибки шлюза (0 — успех или код не распознан).
+func (res *Response) GatewayErrorCode() code.ErrorCode {
+	return code.ErrorCode(res.ErrorCode)
+}
+
+// GatewayErrorCode — числовой код ошибки шлюза.
+// Если success = false, а код ошибки не числовой, возвращается code.ErrorInvalidParameter,
+// чтобы отказ не выглядел как успех.
+func (res *WalletPaymentResponse) GatewayErrorCode() code.ErrorCode {
+	convertedCode, err := strconv.Atoi(string(res.Error.Code))
+	if !res.Success && (err != nil || convertedCode == 0) {
+		return code.ErrorInvalidParameter
+	}
+	return code.ErrorCode(convertedCode)
+}
+
+// GatewayActionCode — код ответа процессинга (actionCode).
+func (res *OrderStatusResponse) GatewayActionCode() code.ActionCode {
+	return code.ActionCode(res.ActionCode)
+}
+
+// GatewayActionCode — код ответа процессинга (actionCode) по операции оплаты.
+func (res *CardPaymentResponse) GatewayActionCode() code.ActionCode {
+	return code.ActionCode(res.ActionCode)
+}
+
+// GatewayActionCode — код ответа процессинга (actionCode) по операции оплаты.
+func (res *WalletPaymentResponse) GatewayActionCode() code.ActionCode {
+	return code.ActionCode(res.ActionCode)
+}
+
+// GatewayOrderID — ID заказа в платёжном шлюзе.
+func (res *RegisterOrderResponse) GatewayOrderID() string {
+	return res.OrderID
+}
+
+// GatewayOrderID — ID заказа в платёжном шлюзе.
+func (res *OrderStatusResponse) GatewayOrderID() string {
+	return res.OrderID
+}
+
+// GatewayOrderID — ID заказа в платёжном шлюзе.
+func (res *WalletPaymentResponse) GatewayOrderID() string {
+	return res.Data.OrderID
+}
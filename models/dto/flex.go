@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Шлюз возвращает одни и те же поля то числом, то строкой (например, errorCode: 0 и errorCode: "0",
+// currency: 398 и currency: "398"). Типы ниже принимают оба варианта, а также null и пустую строку.
+
+// FlexInt — целое число, закодированное в JSON числом или строкой.
+type FlexInt int
+
+// UnmarshalJSON — разбор числа, строки с числом, пустой строки или null.
+func (v *FlexInt) UnmarshalJSON(data []byte) error {
+	n, err := parseFlexInt(data, strconv.IntSize)
+	if err != nil {
+		return err
+	}
+	*v = FlexInt(n)
+	return nil
+}
+
+// FlexInt64 — целое 64-битное число (суммы, Unix-время в мс), закодированное числом или строкой.
+type FlexInt64 int64
+
+// UnmarshalJSON — разбор числа, строки с числом, пустой строки или null.
+func (v *FlexInt64) UnmarshalJSON(data []byte) error {
+	n, err := parseFlexInt(data, 64)
+	if err != nil {
+		return err
+	}
+	*v = FlexInt64(n)
+	return nil
+}
+
+// FlexString — строка, закодированная в JSON строкой или числом (например, currency: 398).
+type FlexString string
+
+// UnmarshalJSON — разбор строки, числа или null.
+func (v *FlexString) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*v = ""
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*v = FlexString(s)
+		return nil
+	default:
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("dto: expected string or number, got %s", data)
+		}
+		*v = FlexString(n.String())
+		return nil
+	}
+}
+
+// parseFlexInt — разбор целого из JSON-числа или строки; null и "" — 0.
+func parseFlexInt(data []byte, bitSize int) (int64, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return 0, nil
+	}
+
+	raw := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return 0, err
+		}
+		if raw == "" {
+			return 0, nil
+		}
+	}
+
+	n, err := strconv.ParseInt(raw, 10, bitSize)
+	if err != nil {
+		// Целое, записанное с дробной частью (например, 100.0)
+		f, ferr := strconv.ParseFloat(raw, 64)
+		if ferr != nil || f != float64(int64(f)) {
+			return 0, fmt.Errorf("dto: expected integer, got %s", data)
+		}
+		return int64(f), nil
+	}
+	return n, nil
+}
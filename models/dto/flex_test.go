@@ -0,0 +1,103 @@
+package dto
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlexInt(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    FlexInt
+		wantErr bool
+	}{
+		{data: `0`, want: 0},
+		{data: `42`, want: 42},
+		{data: `-2007`, want: -2007},
+		{data: `"42"`, want: 42},
+		{data: `"-2007"`, want: -2007},
+		{data: `""`, want: 0},
+		{data: `null`, want: 0},
+		{data: `100.0`, want: 100},
+		{data: `"100.0"`, want: 100},
+		{data: `100.5`, wantErr: true},
+		{data: `"abc"`, wantErr: true},
+		{data: `true`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got FlexInt
+		err := json.Unmarshal([]byte(tt.data), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.data, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestFlexInt64(t *testing.T) {
+	tests := []struct {
+		data string
+		want FlexInt64
+	}{
+		{`1760860800123`, 1760860800123},
+		{`"1760860800123"`, 1760860800123},
+		{`""`, 0},
+		{`null`, 0},
+	}
+
+	for _, tt := range tests {
+		var got FlexInt64
+		if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestFlexString(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    FlexString
+		wantErr bool
+	}{
+		{data: `"398"`, want: "398"},
+		{data: `398`, want: "398"},
+		{data: `"KZT"`, want: "KZT"},
+		{data: `""`, want: ""},
+		{data: `null`, want: ""},
+		{data: `{}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got FlexString
+		err := json.Unmarshal([]byte(tt.data), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.data, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestCurrencyCode(t *testing.T) {
+	tests := map[string]int{
+		"398": 398,
+		"KZT": 398,
+		"840": 840,
+		"":    0,
+	}
+	for value, want := range tests {
+		if got := currencyCode(value); got != want {
+			t.Errorf("currencyCode(%q) = %d, want %d", value, got, want)
+		}
+	}
+}
@@ -151,3 +151,21 @@ type CancelOrderRequest struct {
 	OrderNumber string `json:"orderNumber"` // Номер заказа в системе мерчанта
 	Language    string `json:"language"`    // Язык ответа (ISO 639-1)
 }
+
+// ------------------------------------------------------------
+// Запрос на получение динамического QR-кода
+// ------------------------------------------------------------
+
+type QRRequest struct {
+	OrderID  string `json:"mdOrder"`            // ID заказа в шлюзе
+	QRFormat string `json:"qrFormat,omitempty"` // Формат ответа (matrix — только payload)
+}
+
+// ------------------------------------------------------------
+// Запрос на получение статуса QR-кода
+// ------------------------------------------------------------
+
+type QRStatusRequest struct {
+	OrderID string `json:"mdOrder"` // ID заказа в шлюзе
+	QRID    string `json:"qrId"`    // ID QR-кода
+}
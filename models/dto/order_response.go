@@ -1,7 +1,5 @@
 package dto
 
-import "github.com/bsagat/bereke-merchant-api/models/types"
-
 // ------------------------------------------------------------
 // Базовый ответ API
 // ------------------------------------------------------------
@@ -10,7 +8,7 @@ type Response struct {
 	// Код ошибки:
 	// 0  — успех
 	// 1-99 — ошибка
-	ErrorCode string `json:"errorCode,omitempty"`
+	ErrorCode FlexInt `json:"errorCode,omitempty"`
 
 	// Человеко-читаемое описание ошибки
 	// Язык текста зависит от параметров запроса
@@ -40,26 +38,26 @@ type OrderStatusResponse struct {
 	Response
 
 	// --- Данные заказа ---
-	OrderID     string            `json:"orderId,omitempty"`     // ID заказа в шлюзе
-	OrderNumber string            `json:"orderNumber,omitempty"` // ID заказа в системе мерчанта
-	OrderStatus types.OrderStatus `json:"orderStatus,omitempty"` // Статус заказа (enum)
+	OrderID     string   `json:"orderId,omitempty"`     // ID заказа в шлюзе
+	OrderNumber string   `json:"orderNumber,omitempty"` // ID заказа в системе мерчанта
+	OrderStatus *FlexInt `json:"orderStatus,omitempty"` // Статус заказа (enum types.OrderStatus); nil — поле отсутствует
 
 	// --- Ответ банка ---
-	ActionCode            int    `json:"actionCode,omitempty"`            // Код ответа банка
-	ActionCodeDescription string `json:"actionCodeDescription,omitempty"` // Текстовое описание кода
-	AuthRefNum            string `json:"authRefNum,omitempty"`            // Номер авторизации
-	TerminalID            string `json:"terminalId,omitempty"`            // ID терминала банка
+	ActionCode            FlexInt `json:"actionCode,omitempty"`            // Код ответа банка
+	ActionCodeDescription string  `json:"actionCodeDescription,omitempty"` // Текстовое описание кода
+	AuthRefNum            string  `json:"authRefNum,omitempty"`            // Номер авторизации
+	TerminalID            string  `json:"terminalId,omitempty"`            // ID терминала банка
 
 	// --- Финансовая информация ---
-	MinorAmount int    `json:"amount,omitempty"`   // Сумма заказа в минорных единицах валюты (например, копейки)
-	Currency    string `json:"currency,omitempty"` // Код валюты (ISO 4217)
+	MinorAmount FlexInt    `json:"amount,omitempty"`   // Сумма заказа в минорных единицах валюты (например, копейки)
+	Currency    FlexString `json:"currency,omitempty"` // Код валюты (ISO 4217, числовой или буквенный)
 
 	// --- Временные метки (Unix ms) ---
-	Date          int64 `json:"date,omitempty"`          // Дата создания заказа
-	DepositedDate int64 `json:"depositedDate,omitempty"` // Дата депозита
-	RefundedDate  int64 `json:"refundedDate,omitempty"`  // Дата возврата
-	ReversedDate  int64 `json:"reversedDate,omitempty"`  // Дата сторнирования
-	AuthDateTime  int64 `json:"authDateTime,omitempty"`  // Дата авторизации
+	Date          FlexInt64 `json:"date,omitempty"`          // Дата создания заказа
+	DepositedDate FlexInt64 `json:"depositedDate,omitempty"` // Дата депозита
+	RefundedDate  FlexInt64 `json:"refundedDate,omitempty"`  // Дата возврата
+	ReversedDate  FlexInt64 `json:"reversedDate,omitempty"`  // Дата сторнирования
+	AuthDateTime  FlexInt64 `json:"authDateTime,omitempty"`  // Дата авторизации
 
 	// --- Дополнительная информация ---
 	BindingInfo       BindingInfo       `json:"bindingInfo,omitempty"`       // Информация о привязке карты
@@ -78,26 +76,26 @@ type OrderStatusResponse struct {
 
 // Информация о привязке карты
 type BindingInfo struct {
-	ClientID     string `json:"clientId,omitempty"`     // ID клиента в системе мерчанта (до 255 символов)
-	BindingID    string `json:"bindingId,omitempty"`    // ID привязки карты (до 255 символов)
-	AuthDateTime int64  `json:"authDateTime,omitempty"` // Дата авторизации (мс от Unix epoch)
-	AuthRefNum   string `json:"authRefNum,omitempty"`   // Номер авторизации (до 24 символов)
-	TerminalID   string `json:"terminalId,omitempty"`   // ID терминала (до 10 символов)
+	ClientID     string    `json:"clientId,omitempty"`     // ID клиента в системе мерчанта (до 255 символов)
+	BindingID    string    `json:"bindingId,omitempty"`    // ID привязки карты (до 255 символов)
+	AuthDateTime FlexInt64 `json:"authDateTime,omitempty"` // Дата авторизации (мс от Unix epoch)
+	AuthRefNum   string    `json:"authRefNum,omitempty"`   // Номер авторизации (до 24 символов)
+	TerminalID   string    `json:"terminalId,omitempty"`   // ID терминала (до 10 символов)
 }
 
 // Информация о суммах транзакции
 type PaymentAmountInfo struct {
-	ApprovedAmount  int64  `json:"approvedAmount,omitempty"`  // Одобренная сумма
-	DepositedAmount int64  `json:"depositedAmount,omitempty"` // Депонированная сумма
-	RefundedAmount  int64  `json:"refundedAmount,omitempty"`  // Возвращенная сумма
-	PaymentState    string `json:"paymentState,omitempty"`    // Состояние платежа (CREATED, APPROVED и т.д.)
+	ApprovedAmount  FlexInt64 `json:"approvedAmount,omitempty"`  // Одобренная сумма
+	DepositedAmount FlexInt64 `json:"depositedAmount,omitempty"` // Депонированная сумма
+	RefundedAmount  FlexInt64 `json:"refundedAmount,omitempty"`  // Возвращенная сумма
+	PaymentState    string    `json:"paymentState,omitempty"`    // Состояние платежа (CREATED, APPROVED и т.д.)
 }
 
 // Информация о банке
 type BankInfo struct {
-	BankName        string `json:"bankName,omitempty"`        // Название банка (до 50 символов)
-	BankCountryCode string `json:"bankCountryCode,omitempty"` // Код страны банка (до 4 символов)
-	BankCountryName string `json:"bankCountryName,omitempty"` // Название страны банка (до 160 символов)
+	BankName        string     `json:"bankName,omitempty"`        // Название банка (до 50 символов)
+	BankCountryCode FlexString `json:"bankCountryCode,omitempty"` // Код страны банка (числовой или буквенный, до 4 символов)
+	BankCountryName string     `json:"bankCountryName,omitempty"` // Название страны банка (до 160 символов)
 }
 
 // Информация о карте
@@ -108,3 +106,26 @@ type CardInfo struct {
 	Pan            string `json:"pan,omitempty"`            // Полный номер карты (до 19 символов)
 	ApprovalCode   string `json:"approvalCode,omitempty"`   // Код авторизации (до 6 символов)
 }
+
+// ------------------------------------------------------------
+// Ответ с динамическим QR-кодом
+// ------------------------------------------------------------
+
+type QRResponse struct {
+	Response
+
+	QRID     string `json:"qrId,omitempty"`     // ID QR-кода
+	Payload  string `json:"payload,omitempty"`  // Содержимое QR-кода
+	QRStatus string `json:"qrStatus,omitempty"` // Статус QR-кода
+}
+
+// ------------------------------------------------------------
+// Ответ со статусом QR-кода
+// ------------------------------------------------------------
+
+type QRStatusResponse struct {
+	Response
+
+	QRStatus         string `json:"qrStatus,omitempty"`         // Статус QR-кода
+	TransactionState string `json:"transactionState,omitempty"` // Состояние транзакции
+}
@@ -0,0 +1,96 @@
+package dto
+
+// ------------------------------------------------------------
+// Запрос на оплату через Apple Pay
+// ------------------------------------------------------------
+
+type ApplePayRequest struct {
+	Merchant             string            `json:"merchant"`                       // Логин мерчанта
+	OrderNumber          string            `json:"orderNumber"`                    // Номер заказа в системе мерчанта
+	Description          string            `json:"description,omitempty"`          // Описание заказа
+	Language             string            `json:"language,omitempty"`             // Язык ответа (ISO 639-1)
+	AdditionalParameters map[string]string `json:"additionalParameters,omitempty"` // Дополнительные параметры заказа
+	PreAuth              bool              `json:"preAuth,omitempty"`              // Двухстадийный платёж
+	PaymentToken         string            `json:"paymentToken"`                   // Платёжный токен в base64
+}
+
+// ------------------------------------------------------------
+// Запрос на оплату через Google Pay
+// ------------------------------------------------------------
+
+type GooglePayRequest struct {
+	Merchant             string            `json:"merchant"`                       // Логин мерчанта
+	OrderNumber          string            `json:"orderNumber"`                    // Номер заказа в системе мерчанта
+	Description          string            `json:"description,omitempty"`          // Описание заказа
+	Language             string            `json:"language,omitempty"`             // Язык ответа (ISO 639-1)
+	AdditionalParameters map[string]string `json:"additionalParameters,omitempty"` // Дополнительные параметры заказа
+	PreAuth              bool              `json:"preAuth,omitempty"`              // Двухстадийный платёж
+	ClientID             string            `json:"clientId,omitempty"`             // Идентификатор клиента
+	PaymentToken         string            `json:"paymentToken"`                   // Платёжный токен в base64
+	AuthMethod           string            `json:"authMethod,omitempty"`           // Тип токена: PAN_ONLY или CRYPTOGRAM_3DS
+	IP                   string            `json:"ip,omitempty"`                   // IP-адрес клиента
+	Amount               int               `json:"amount"`                         // Сумма в минимальных единицах валюты
+	CurrencyCode         int               `json:"currencyCode,omitempty"`         // Код валюты (ISO 4217)
+	Email                string            `json:"email,omitempty"`                // Email клиента
+	Phone                string            `json:"phone,omitempty"`                // Телефон клиента
+	ReturnURL            string            `json:"returnUrl,omitempty"`            // URL возврата после успешной оплаты
+	FailURL              string            `json:"failUrl,omitempty"`              // URL возврата при ошибке
+}
+
+// ------------------------------------------------------------
+// Запрос на оплату заказа данными карты
+// ------------------------------------------------------------
+
+type CardPaymentRequest struct {
+	OrderID          string `json:"MDORDER"`                    // ID заказа в шлюзе
+	PAN              string `json:"$PAN"`                       // Номер карты
+	CVC              string `json:"$CVC"`                       // CVC/CVV2 код
+	Year             string `json:"YYYY"`                       // Год окончания срока действия
+	Month            string `json:"MM"`                         // Месяц окончания срока действия
+	CardholderName   string `json:"TEXT,omitempty"`             // Имя держателя карты
+	Language         string `json:"language,omitempty"`         // Язык ответа (ISO 639-1)
+	IP               string `json:"ip,omitempty"`               // IP-адрес клиента
+	Email            string `json:"email,omitempty"`            // Email клиента
+	JSONParams       string `json:"jsonParams,omitempty"`       // Дополнительные параметры в JSON
+	BindingNotNeeded bool   `json:"bindingNotNeeded,omitempty"` // Не создавать связку
+}
+
+// ------------------------------------------------------------
+// Запрос на проверку вовлечённости карты в 3-D Secure
+// ------------------------------------------------------------
+
+type EnrollmentRequest struct {
+	PAN string `json:"pan"` // Номер карты
+}
+
+// ------------------------------------------------------------
+// Запрос на продолжение оплаты по 3-D Secure 2
+// ------------------------------------------------------------
+
+type ThreeDS2Request struct {
+	CardPaymentRequest
+
+	ThreeDSServerTransID  string `json:"threeDSServerTransId"`                   // ID транзакции на 3DS-сервере
+	FinishURL             string `json:"threeDSVer2FinishUrl,omitempty"`         // URL возврата после challenge
+	MethodNotificationURL string `json:"threeDSMethodNotificationUrl,omitempty"` // URL уведомления о 3DS Method
+
+	BrowserAcceptHeader string `json:"browserAcceptHeader,omitempty"` // Заголовок Accept
+	BrowserUserAgent    string `json:"browserUserAgent,omitempty"`    // Заголовок User-Agent
+	BrowserLanguage     string `json:"browserLanguage,omitempty"`     // Язык браузера
+	BrowserColorDepth   int    `json:"browserColorDepth,omitempty"`   // Глубина цвета
+	BrowserScreenHeight int    `json:"browserScreenHeight,omitempty"` // Высота экрана
+	BrowserScreenWidth  int    `json:"browserScreenWidth,omitempty"`  // Ширина экрана
+	BrowserTZ           int    `json:"browserTZ"`                     // Смещение часового пояса (мин)
+	BrowserJavaEnabled  bool   `json:"browserJavaEnabled"`            // Java включена
+	BrowserJsEnabled    bool   `json:"browserJsEnabled"`              // JavaScript включён
+	BrowserIPAddress    string `json:"browserIpAddress,omitempty"`    // IP-адрес клиента
+}
+
+// ------------------------------------------------------------
+// Запрос на завершение 3-D Secure 2
+// ------------------------------------------------------------
+
+type FinishThreeDSRequest struct {
+	TransID  string `json:"tDsTransId"`         // ID транзакции на 3DS-сервере
+	Language string `json:"language,omitempty"` // Язык ответа (ISO 639-1)
+}
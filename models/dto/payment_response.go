@@ -0,0 +1,68 @@
+package dto
+
+// ------------------------------------------------------------
+// Ответ платёжных endpoint'ов (applepay/payment.do, google/payment.do)
+// ------------------------------------------------------------
+
+type WalletPaymentResponse struct {
+	// true — если запрос успешно обработан
+	Success bool `json:"success"`
+
+	// Данные об оплате
+	Data WalletPaymentData `json:"data,omitempty"`
+
+	// Информация об ошибке (при success = false)
+	Error WalletError `json:"error,omitempty"`
+
+	// Код ответа процессинга по операции (если шлюз его вернул)
+	ActionCode FlexInt `json:"actionCode,omitempty"`
+}
+
+// Данные об оплате
+type WalletPaymentData struct {
+	OrderID string `json:"orderId,omitempty"` // ID заказа в шлюзе
+	ACSUrl  string `json:"acsUrl,omitempty"`  // URL ACS банка-эмитента (если требуется 3DS)
+	PaReq   string `json:"paReq,omitempty"`   // Запрос на аутентификацию 3DS
+	TermURL string `json:"termUrl,omitempty"` // URL возврата после 3DS
+}
+
+// Информация об ошибке
+type WalletError struct {
+	Code        FlexString `json:"code,omitempty"`        // Код ошибки
+	Description string     `json:"description,omitempty"` // Описание ошибки
+	Message     string     `json:"message,omitempty"`     // Сообщение об ошибке
+}
+
+// ------------------------------------------------------------
+// Ответ на оплату заказа данными карты (paymentorder.do, finishThreeDs.do)
+// ------------------------------------------------------------
+
+type CardPaymentResponse struct {
+	Response
+
+	Redirect   string  `json:"redirect,omitempty"`   // URL для перенаправления клиента
+	Info       string  `json:"info,omitempty"`       // Результат попытки оплаты
+	ActionCode FlexInt `json:"actionCode,omitempty"` // Код ответа процессинга по операции
+	ACSUrl     string  `json:"acsUrl,omitempty"`     // URL ACS банка-эмитента (если требуется 3DS)
+	PaReq      string  `json:"paReq,omitempty"`      // Запрос на аутентификацию 3DS
+	TermURL    string  `json:"termUrl,omitempty"`    // URL возврата после 3DS
+
+	// --- 3-D Secure 2 ---
+	Is3DSVer2               bool   `json:"is3DSVer2,omitempty"`               // Аутентификация по 3DS2
+	ThreeDSServerTransID    string `json:"threeDSServerTransId,omitempty"`    // ID транзакции на 3DS-сервере
+	ThreeDSMethodURL        string `json:"threeDSMethodURL,omitempty"`        // URL 3DS Method эмитента
+	ThreeDSMethodDataPacked string `json:"threeDSMethodDataPacked,omitempty"` // Данные для 3DS Method
+	PackedCReq              string `json:"packedCReq,omitempty"`              // Упакованный CReq для ACS
+}
+
+// ------------------------------------------------------------
+// Ответ на проверку вовлечённости карты в 3-D Secure
+// ------------------------------------------------------------
+
+type EnrollmentResponse struct {
+	Response
+
+	Enrolled           string `json:"enrolled,omitempty"`           // Y / N / U
+	EmitterName        string `json:"emitterName,omitempty"`        // Название банка-эмитента
+	EmitterCountryCode string `json:"emitterCountryCode,omitempty"` // Код страны банка-эмитента
+}
@@ -151,3 +151,104 @@ func (r CancelOrderRequest) ToUrlValues() url.Values {
 
 	return values
 }
+
+func (r CardPaymentRequest) ToUrlValues() url.Values {
+	values := url.Values{}
+	values.Set("MDORDER", r.OrderID)
+	values.Set("$PAN", r.PAN)
+	values.Set("$CVC", r.CVC)
+	values.Set("YYYY", r.Year)
+	values.Set("MM", r.Month)
+
+	if r.CardholderName != "" {
+		values.Set("TEXT", r.CardholderName)
+	}
+	if r.Language != "" {
+		values.Set("language", r.Language)
+	}
+	if r.IP != "" {
+		values.Set("ip", r.IP)
+	}
+	if r.Email != "" {
+		values.Set("email", r.Email)
+	}
+	if r.JSONParams != "" {
+		values.Set("jsonParams", r.JSONParams)
+	}
+	if r.BindingNotNeeded {
+		values.Set("bindingNotNeeded", "true")
+	}
+
+	return values
+}
+
+func (r EnrollmentRequest) ToUrlValues() url.Values {
+	values := url.Values{}
+	values.Set("pan", r.PAN)
+	return values
+}
+
+func (r ThreeDS2Request) ToUrlValues() url.Values {
+	values := r.CardPaymentRequest.ToUrlValues()
+	values.Set("threeDSServerTransId", r.ThreeDSServerTransID)
+
+	if r.FinishURL != "" {
+		values.Set("threeDSVer2FinishUrl", r.FinishURL)
+	}
+	if r.MethodNotificationURL != "" {
+		values.Set("threeDSMethodNotificationUrl", r.MethodNotificationURL)
+	}
+	if r.BrowserAcceptHeader != "" {
+		values.Set("browserAcceptHeader", r.BrowserAcceptHeader)
+	}
+	if r.BrowserUserAgent != "" {
+		values.Set("browserUserAgent", r.BrowserUserAgent)
+	}
+	if r.BrowserLanguage != "" {
+		values.Set("browserLanguage", r.BrowserLanguage)
+	}
+	if r.BrowserColorDepth != 0 {
+		values.Set("browserColorDepth", strconv.Itoa(r.BrowserColorDepth))
+	}
+	if r.BrowserScreenHeight != 0 {
+		values.Set("browserScreenHeight", strconv.Itoa(r.BrowserScreenHeight))
+	}
+	if r.BrowserScreenWidth != 0 {
+		values.Set("browserScreenWidth", strconv.Itoa(r.BrowserScreenWidth))
+	}
+	if r.BrowserIPAddress != "" {
+		values.Set("browserIpAddress", r.BrowserIPAddress)
+	}
+	values.Set("browserTZ", strconv.Itoa(r.BrowserTZ))
+	values.Set("browserJavaEnabled", strconv.FormatBool(r.BrowserJavaEnabled))
+	values.Set("browserJsEnabled", strconv.FormatBool(r.BrowserJsEnabled))
+
+	return values
+}
+
+func (r FinishThreeDSRequest) ToUrlValues() url.Values {
+	values := url.Values{}
+	values.Set("tDsTransId", r.TransID)
+
+	if r.Language != "" {
+		values.Set("language", r.Language)
+	}
+	return values
+}
+
+func (r QRRequest) ToUrlValues() url.Values {
+	values := url.Values{}
+	values.Set("mdOrder", r.OrderID)
+
+	if r.QRFormat != "" {
+		values.Set("qrFormat", r.QRFormat)
+	}
+	return values
+}
+
+func (r QRStatusRequest) ToUrlValues() url.Values {
+	values := url.Values{}
+	values.Set("mdOrder", r.OrderID)
+	values.Set("qrId", r.QRID)
+	return values
+}
@@ -0,0 +1,21 @@
+package types
+
+type CallbackOperation string
+
+const (
+	CallbackApproved          CallbackOperation = "approved"          // Средства заблокированы (предавторизация)
+	CallbackDeposited         CallbackOperation = "deposited"         // Средства списаны
+	CallbackReversed          CallbackOperation = "reversed"          // Авторизация отменена
+	CallbackRefunded          CallbackOperation = "refunded"          // Выполнен возврат
+	CallbackDeclinedByTimeout CallbackOperation = "declinedByTimeout" // Заказ отклонён по истечении срока оплаты
+)
+
+// Valid — операция поддерживается шлюзом.
+func (o CallbackOperation) Valid() bool {
+	switch o {
+	case CallbackApproved, CallbackDeposited, CallbackReversed, CallbackRefunded, CallbackDeclinedByTimeout:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,8 @@
+package types
+
+type GooglePayAuthMethod string
+
+const (
+	GooglePayPanOnly       GooglePayAuthMethod = "PAN_ONLY"       // Карта, сохранённая в аккаунте Google (требуется 3-D Secure)
+	GooglePayCryptogram3DS GooglePayAuthMethod = "CRYPTOGRAM_3DS" // Токенизированная карта устройства (криптограмма 3DS)
+)
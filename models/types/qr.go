@@ -0,0 +1,35 @@
+package types
+
+type QRImageFormat string
+
+const (
+	QRImagePNG QRImageFormat = "png" // PNG-изображение
+	QRImageSVG QRImageFormat = "svg" // SVG-изображение
+)
+
+type QRStatus string
+
+const (
+	QRStarted        QRStatus = "STARTED"          // QR-код создан, ожидается оплата
+	QRConfirmed      QRStatus = "CONFIRMED"        // Клиент подтвердил оплату, операция в обработке
+	QRAccepted       QRStatus = "ACCEPTED"         // Оплата по QR-коду прошла успешно
+	QRRejected       QRStatus = "REJECTED"         // Оплата отклонена
+	QRRejectedByUser QRStatus = "REJECTED_BY_USER" // Оплата отклонена клиентом
+)
+
+// OrderStatus — соответствие статуса QR-кода статусу заказа.
+// Неизвестные статусы считаются зарегистрированным, но не оплаченным заказом.
+func (s QRStatus) OrderStatus() OrderStatus {
+	switch s {
+	case QRStarted:
+		return OrderStatusWaiting
+	case QRConfirmed:
+		return OrderStatusPending
+	case QRAccepted:
+		return OrderStatusCompleted
+	case QRRejected, QRRejectedByUser:
+		return OrderStatusDeclined
+	default:
+		return OrderStatusRegistered
+	}
+}
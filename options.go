@@ -0,0 +1,143 @@
+package bereke_merchant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bsagat/bereke-merchant-api/internal/ratelimit"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/ordernumber"
+)
+
+// Option — дополнительная настройка API клиента.
+// Передаётся последним аргументом в NewWithLogin, NewWithToken и NewWithCertificate.
+type Option func(*api)
+
+// WithRateLimit — глобальное ограничение частоты запросов к шлюзу (token bucket).
+// Аргументы:
+//   - rps — количество запросов в секунду
+//   - burst — максимальное количество запросов, которое можно отправить разом
+//
+// При превышении лимита запрос ожидает свободный токен. Если дедлайн ctx наступит
+// раньше, запрос не отправляется и возвращается ошибка context.DeadlineExceeded.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(a *api) {
+		if rps > 0 {
+			a.limiter = ratelimit.NewLimiter(rps, burst)
+		}
+	}
+}
+
+// WithEndpointRateLimit — ограничение частоты запросов к отдельному endpoint
+// (например, "register.do"). Действует вместе с глобальным лимитом WithRateLimit.
+func WithEndpointRateLimit(endpoint string, rps float64, burst int) Option {
+	return func(a *api) {
+		if rps <= 0 {
+			return
+		}
+		if a.endpointLimiters == nil {
+			a.endpointLimiters = map[string]*ratelimit.Limiter{}
+		}
+		a.endpointLimiters[endpoint] = ratelimit.NewLimiter(rps, burst)
+	}
+}
+
+// WithMaxInFlight — ограничение количества одновременно выполняемых запросов к шлюзу.
+// Запросы сверх лимита ожидают освобождения слота с учётом ctx.
+func WithMaxInFlight(n int) Option {
+	return func(a *api) {
+		if n > 0 {
+			a.inFlight = ratelimit.NewSemaphore(n)
+		}
+	}
+}
+
+// acquire — ожидание разрешения на запрос к endpoint с учётом лимитов клиента.
+// Сначала ожидаются токены глобального и endpoint-лимита, и только затем занимается
+// слот WithMaxInFlight: запросы в очереди лимитера не расходуют слоты одновременных запросов.
+// Возвращает функцию, которую нужно вызвать после завершения запроса.
+func (a *api) acquire(ctx context.Context, endpoint string) (func(), error) {
+	if a.limiter != nil {
+		if err := a.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if limiter, ok := a.endpointLimiters[endpoint]; ok {
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if a.inFlight == nil {
+		return func() {}, nil
+	}
+	if err := a.inFlight.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	return a.inFlight.Release, nil
+}
+
+// WithHTTPClient — HTTP-клиент для запросов к шлюзу (по умолчанию http.DefaultClient).
+// Позволяет задать собственный Transport: прокси, mTLS, запись/воспроизведение трафика
+// в тестах (пакет bereketest/recorder).
+func WithHTTPClient(client *http.Client) Option {
+	return func(a *api) {
+		a.httpClient = client
+	}
+}
+
+// WithGatewayURL — адрес шлюза вместо стандартного для режима (TEST/PROD),
+// например локальной песочницы cmd/bereke-sandbox: "http://localhost:8080/payment/".
+// REST endpoint'ы вызываются по адресу <url>rest/, платёжные (Apple Pay, Google Pay) — по <url>.
+func WithGatewayURL(url string) Option {
+	return func(a *api) {
+		url = strings.TrimRight(url, "/") + "/"
+		a.baseURL = url + "rest"
+		a.paymentURL = url
+	}
+}
+
+// client — HTTP-клиент для запросов к шлюзу.
+func (a *api) client() *http.Client {
+	if a.httpClient != nil {
+		return a.httpClient
+	}
+	return http.DefaultClient
+}
+
+// WithOrderNumberGenerator — генерация номера заказа в RegisterOrder/AuthOrder,
+// если OrderNumber в запросе не задан.
+func WithOrderNumberGenerator(gen ordernumber.Generator) Option {
+	return func(a *api) {
+		a.orderNumbers = gen
+	}
+}
+
+// prepareOrder — подстановка номера заказа из генератора и локальная проверка заказа.
+func (a *api) prepareOrder(order *core.Order) error {
+	if order.OrderNumber == "" && a.orderNumbers != nil {
+		number, err := a.orderNumbers.Next()
+		if err != nil {
+			return fmt.Errorf("generate order number: %w", err)
+		}
+		order.OrderNumber = number
+	}
+	return order.Validate()
+}
+
+// WithExperimentalQR — включение экспериментальных методов RegisterQR и GetQRStatus.
+// Пустые поля endpoints заменяются путями из DefaultQREndpoints, которые банк не подтвердил
+// в документации: используйте QR-коды только после согласования сервиса с банком.
+func WithExperimentalQR(endpoints QREndpoints) Option {
+	return func(a *api) {
+		if endpoints.Dynamic == "" {
+			endpoints.Dynamic = DefaultQREndpoints.Dynamic
+		}
+		if endpoints.Status == "" {
+			endpoints.Status = DefaultQREndpoints.Status
+		}
+		a.qr = &endpoints
+	}
+}
@@ -0,0 +1,37 @@
+package bereke_merchant
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAcquireWaitsForLimiterBeforeSlot(t *testing.T) {
+	a := &api{}
+	WithRateLimit(20, 1)(a)
+	WithMaxInFlight(1)(a)
+
+	ctx := context.Background()
+	release, err := a.acquire(ctx, "register.do")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+
+	// Второй запрос ждёт токен лимитера, не занимая слот одновременных запросов
+	done := make(chan error, 1)
+	go func() {
+		release, err := a.acquire(ctx, "register.do")
+		if err == nil {
+			release()
+		}
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if len(a.inFlight) != 0 {
+		t.Error("in-flight slot is held while waiting for the rate limiter")
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,7 +2,6 @@ package bereke_merchant
 
 import (
 	"context"
-	"io"
 
 	"github.com/bsagat/bereke-merchant-api/models/core"
 	"github.com/bsagat/bereke-merchant-api/models/dto"
@@ -14,12 +13,19 @@ import (
 // Аргументы:
 //   - req — структура RegisterOrderRequest с номером заказа, суммой, валютой и URL для редиректов.
 //
+// Перед отправкой заказ проверяется локально (core.Order.Validate); при нарушениях
+// возвращается *core.ValidationError. Пустой номер заказа заполняется генератором
+// из WithOrderNumberGenerator, если он подключён.
+//
 // Возвращает RegisterOrderResponse с ID заказа и ссылкой для оплаты.
 func (a *api) RegisterOrder(ctx context.Context, req core.RegisterOrderRequest) (core.RegisterOrderResponse, error) {
+	if err := a.prepareOrder(&req.Order); err != nil {
+		return core.RegisterOrderResponse{}, err
+	}
 	reqParams := dto.FromCoreRegisterOrder(req).ToUrlValues()
 
 	var response dto.RegisterOrderResponse
-	if err := a.sendRequest(ctx, POST, "register.do", reqParams, &response); err != nil && err != io.EOF {
+	if err := a.sendRequest(ctx, POST, "register.do", reqParams, &response); err != nil {
 		return core.RegisterOrderResponse{}, err
 	}
 
@@ -30,11 +36,15 @@ func (a *api) RegisterOrder(ctx context.Context, req core.RegisterOrderRequest)
 // Отправляет запрос в endpoint `registerPreAuth.do`.
 // В этом случае средства блокируются, но не списываются.
 // Чтобы завершить платёж, необходимо вызвать DepositOrder.
+// Заказ проверяется и дополняется номером так же, как в RegisterOrder.
 func (a *api) AuthOrder(ctx context.Context, req core.RegisterOrderRequest) (core.RegisterOrderResponse, error) {
+	if err := a.prepareOrder(&req.Order); err != nil {
+		return core.RegisterOrderResponse{}, err
+	}
 	reqParams := dto.FromCoreRegisterOrder(req).ToUrlValues()
 
 	var response dto.RegisterOrderResponse
-	if err := a.sendRequest(ctx, POST, "registerPreAuth.do", reqParams, &response); err != nil && err != io.EOF {
+	if err := a.sendRequest(ctx, POST, "registerPreAuth.do", reqParams, &response); err != nil {
 		return core.RegisterOrderResponse{}, err
 	}
 
@@ -52,7 +62,7 @@ func (a *api) DepositOrder(ctx context.Context, req core.DepositOrderRequest) (c
 	reqParams := dto.FromCoreDepositOrder(req).ToUrlValues()
 
 	var response dto.Response
-	if err := a.sendRequest(ctx, POST, "deposit.do", reqParams, &response); err != nil && err != io.EOF {
+	if err := a.sendRequest(ctx, POST, "deposit.do", reqParams, &response); err != nil {
 		return core.Response{}, err
 	}
 
@@ -66,7 +76,7 @@ func (a *api) RefundOrder(ctx context.Context, req core.RefundOrderRequest) (cor
 	reqParams := dto.FromCoreRefundOrder(req).ToUrlValues()
 
 	var response dto.Response
-	if err := a.sendRequest(ctx, POST, "refund.do", reqParams, &response); err != nil && err != io.EOF {
+	if err := a.sendRequest(ctx, POST, "refund.do", reqParams, &response); err != nil {
 		return core.Response{}, err
 	}
 	return response.DtoToCore(), nil
@@ -80,7 +90,7 @@ func (a *api) ReversalOrder(ctx context.Context, req core.ReversalOrderRequest)
 	reqParams := dto.FromCoreReversalOrder(req).ToUrlValues()
 
 	var response dto.Response
-	if err := a.sendRequest(ctx, POST, "reverse.do", reqParams, &response); err != nil && err != io.EOF {
+	if err := a.sendRequest(ctx, POST, "reverse.do", reqParams, &response); err != nil {
 		return core.Response{}, err
 	}
 	return response.DtoToCore(), nil
@@ -96,7 +106,7 @@ func (a *api) CancelOrder(ctx context.Context, req core.CancelOrderRequest) (cor
 	reqParams := dto.FromCoreCancelOrder(req).ToUrlValues()
 
 	var response dto.Response
-	if err := a.sendRequest(ctx, POST, "decline.do", reqParams, &response); err != nil && err != io.EOF {
+	if err := a.sendRequest(ctx, POST, "decline.do", reqParams, &response); err != nil {
 		return core.Response{}, err
 	}
 	return response.DtoToCore(), nil
@@ -0,0 +1,148 @@
+// Package ordernumber — генераторы уникальных номеров заказов (OrderNumber).
+//
+// Номер заказа должен быть уникален в пределах мерчанта и не длиннее 36 символов.
+// Генераторы подключаются к клиенту опцией WithOrderNumberGenerator: RegisterOrder/AuthOrder
+// подставляют номер, если OrderNumber в запросе пустой.
+//
+//	api, err := bereke_merchant.NewWithLogin(login, password, types.PROD,
+//		bereke_merchant.WithOrderNumberGenerator(ordernumber.UUIDv7()))
+package ordernumber
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Generator — источник номеров заказов.
+type Generator interface {
+	// Next — следующий уникальный номер заказа.
+	Next() (string, error)
+}
+
+// GeneratorFunc — адаптер функции к интерфейсу Generator.
+type GeneratorFunc func() (string, error)
+
+// Next — вызов функции.
+func (f GeneratorFunc) Next() (string, error) {
+	return f()
+}
+
+// UUIDv7 — генератор UUID версии 7 (RFC 9562): 36 символов,
+// упорядочены по времени создания, 74 бита случайности.
+func UUIDv7() Generator {
+	return GeneratorFunc(func() (string, error) {
+		var b [16]byte
+		if _, err := rand.Read(b[6:]); err != nil {
+			return "", err
+		}
+
+		ms := uint64(time.Now().UnixMilli())
+		b[0], b[1], b[2] = byte(ms>>40), byte(ms>>32), byte(ms>>24)
+		b[3], b[4], b[5] = byte(ms>>16), byte(ms>>8), byte(ms)
+		b[6] = b[6]&0x0f | 0x70 // версия 7
+		b[8] = b[8]&0x3f | 0x80 // вариант RFC 9562
+
+		var s [36]byte
+		hex.Encode(s[0:8], b[0:4])
+		s[8] = '-'
+		hex.Encode(s[9:13], b[4:6])
+		s[13] = '-'
+		hex.Encode(s[14:18], b[6:8])
+		s[18] = '-'
+		hex.Encode(s[19:23], b[8:10])
+		s[23] = '-'
+		hex.Encode(s[24:], b[10:])
+		return string(s[:]), nil
+	})
+}
+
+// crockford — алфавит Crockford Base32 (без I, L, O, U).
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID — генератор ULID: 26 символов Crockford Base32,
+// упорядочены по времени создания, 80 бит случайности.
+func ULID() Generator {
+	return GeneratorFunc(func() (string, error) {
+		var b [16]byte
+		if _, err := rand.Read(b[6:]); err != nil {
+			return "", err
+		}
+		ms := uint64(time.Now().UnixMilli())
+		binary.BigEndian.PutUint16(b[0:2], uint16(ms>>32))
+		binary.BigEndian.PutUint32(b[2:6], uint32(ms))
+
+		// 128 бит кодируются 26 символами по 5 бит, старший символ — 3 бита
+		hi := binary.BigEndian.Uint64(b[0:8])
+		lo := binary.BigEndian.Uint64(b[8:16])
+		var s [26]byte
+		for i := 25; i >= 0; i-- {
+			s[i] = crockford[lo&0x1f]
+			lo = lo>>5 | hi<<59
+			hi >>= 5
+		}
+		return string(s[:]), nil
+	})
+}
+
+// timestampLayout — метка времени с миллисекундами (UTC), 17 символов.
+const timestampLayout = "20060102150405.000"
+
+// counterDigits — разрядность счётчика в номере.
+const (
+	counterDigits = 4
+	maxCounter    = 9999
+)
+
+// maxPrefixLength — максимальная длина префикса, при которой номер укладывается в 36 символов.
+const maxPrefixLength = 36 - 17 - counterDigits
+
+// Prefixed — генератор номеров вида <prefix><yyyyMMddHHmmssSSS><counter>,
+// например "shop1-202510191203044570042". Счётчик сбрасывается в начале каждой миллисекунды;
+// если за одну миллисекунду он исчерпан, номер получает метку следующей миллисекунды,
+// поэтому номера одного генератора не повторяются и при переводе часов назад.
+//
+// Префикс должен быть уникален для каждого экземпляра сервиса, иначе номера
+// разных экземпляров могут совпасть.
+func Prefixed(prefix string) (Generator, error) {
+	if len(prefix) > maxPrefixLength {
+		return nil, fmt.Errorf("order number prefix must be at most %d bytes", maxPrefixLength)
+	}
+	if prefix == "" {
+		return nil, errors.New("order number prefix is required")
+	}
+	return &prefixed{prefix: prefix}, nil
+}
+
+type prefixed struct {
+	prefix string
+
+	mu      sync.Mutex
+	last    int64 // последняя использованная миллисекунда
+	counter int
+}
+
+// Next — следующий номер заказа.
+func (g *prefixed) Next() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := time.Now().UnixMilli()
+	switch {
+	case ms > g.last:
+		g.last, g.counter = ms, 0
+	case g.counter >= maxCounter:
+		// Счётчик исчерпан (или часы отошли назад) — занимаем следующую миллисекунду
+		g.last, g.counter = g.last+1, 0
+	default:
+		g.counter++
+	}
+
+	stamp := time.UnixMilli(g.last).UTC().Format(timestampLayout)
+	stamp = stamp[:14] + stamp[15:] // без точки перед миллисекундами
+	return fmt.Sprintf("%s%s%0*d", g.prefix, stamp, counterDigits, g.counter), nil
+}
@@ -0,0 +1,119 @@
+package ordernumber
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGenerators(t *testing.T) {
+	prefixed, err := Prefixed("shop1-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		gen     Generator
+		pattern *regexp.Regexp
+	}{
+		{"UUIDv7", UUIDv7(), regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+		{"ULID", ULID(), regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)},
+		{"Prefixed", prefixed, regexp.MustCompile(`^shop1-\d{21}$`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const workers, perWorker = 8, 2000
+
+			var (
+				mu   sync.Mutex
+				seen = make(map[string]bool, workers*perWorker)
+				wg   sync.WaitGroup
+			)
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < perWorker; i++ {
+						number, err := tt.gen.Next()
+						if err != nil {
+							t.Error(err)
+							return
+						}
+						if len(number) > 36 {
+							t.Errorf("%q: length %d exceeds 36", number, len(number))
+						}
+						if !tt.pattern.MatchString(number) {
+							t.Errorf("%q does not match %s", number, tt.pattern)
+						}
+
+						mu.Lock()
+						if seen[number] {
+							t.Errorf("duplicate number %q", number)
+						}
+						seen[number] = true
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestPrefixedOrdered(t *testing.T) {
+	gen, err := Prefixed("p")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prev, _ := gen.Next()
+	for i := 0; i < 20000; i++ {
+		next, err := gen.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next <= prev {
+			t.Fatalf("%q is not after %q", next, prev)
+		}
+		prev = next
+	}
+}
+
+func TestPrefixedLimits(t *testing.T) {
+	if _, err := Prefixed(""); err == nil {
+		t.Error("empty prefix: expected error")
+	}
+	if _, err := Prefixed(strings.Repeat("x", maxPrefixLength+1)); err == nil {
+		t.Errorf("prefix of %d bytes: expected error", maxPrefixLength+1)
+	}
+
+	gen, err := Prefixed(strings.Repeat("x", maxPrefixLength))
+	if err != nil {
+		t.Fatal(err)
+	}
+	number, err := gen.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(number) != 36 {
+		t.Errorf("number with the longest prefix has length %d, want 36", len(number))
+	}
+}
+
+func TestPrefixedCounterOverflow(t *testing.T) {
+	// Метка в будущем: часы «отстают» от последнего номера, счётчик исчерпан
+	last := time.Date(2099, 1, 2, 3, 4, 5, 678e6, time.UTC).UnixMilli()
+	gen := &prefixed{prefix: "p", last: last, counter: maxCounter}
+
+	number, err := gen.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "p209901020304056790000"; number != want {
+		t.Errorf("number = %q, want %q (next millisecond, counter 0)", number, want)
+	}
+}
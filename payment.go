@@ -0,0 +1,106 @@
+package bereke_merchant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/dto"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+// PayWithApplePay — оплата заказа через Apple Pay.
+// Endpoint: `applepay/payment.do`.
+// Заказ регистрируется и оплачивается одним запросом по платёжному токену,
+// полученному от Apple Pay JS на странице оформления заказа.
+// Аргументы:
+//   - req — структура ApplePayRequest с номером заказа и платёжным токеном (base64).
+//
+// Возвращает PaymentResponse с ID заказа. Если банк-эмитент требует 3-D Secure,
+// поле ThreeDS содержит данные для перенаправления клиента на ACS.
+func (a *api) PayWithApplePay(ctx context.Context, req core.ApplePayRequest) (core.PaymentResponse, error) {
+	if req.Merchant == "" {
+		req.Merchant = a.merchantLogin()
+	}
+	if req.Merchant == "" {
+		return core.PaymentResponse{}, errors.New("merchant login is required for Apple Pay payment")
+	}
+	if req.PaymentToken == "" {
+		return core.PaymentResponse{}, errors.New("payment token is required")
+	}
+
+	var response dto.WalletPaymentResponse
+	if err := a.sendJSONRequest(ctx, "applepay/payment.do", dto.FromCoreApplePay(req), &response); err != nil {
+		return core.PaymentResponse{}, err
+	}
+
+	return response.DtoToCore(), nil
+}
+
+// PayWithGooglePay — оплата заказа через Google Pay.
+// Endpoint: `google/payment.do`.
+// Поддерживаются токены обоих типов:
+//   - CRYPTOGRAM_3DS — токенизированная карта устройства, 3-D Secure не требуется;
+//   - PAN_ONLY — карта из аккаунта Google, банк может потребовать 3-D Secure,
+//     поэтому необходимо указать ReturnURL.
+//
+// Сумма передаётся в основных единицах валюты и переводится в минорные единицы автоматически.
+// Возвращает PaymentResponse с ID заказа и данными 3DS (если требуется).
+func (a *api) PayWithGooglePay(ctx context.Context, req core.GooglePayRequest) (core.PaymentResponse, error) {
+	if req.Merchant == "" {
+		req.Merchant = a.merchantLogin()
+	}
+	if req.Merchant == "" {
+		return core.PaymentResponse{}, errors.New("merchant login is required for Google Pay payment")
+	}
+	if req.PaymentToken == "" {
+		return core.PaymentResponse{}, errors.New("payment token is required")
+	}
+	if req.Amount <= 0 {
+		return core.PaymentResponse{}, errors.New("amount must be positive")
+	}
+
+	switch req.AuthMethod {
+	case types.GooglePayPanOnly:
+		if req.ReturnURL == "" {
+			return core.PaymentResponse{}, errors.New("return URL is required for PAN_ONLY tokens (3-D Secure redirect)")
+		}
+	case types.GooglePayCryptogram3DS, "":
+	default:
+		return core.PaymentResponse{}, fmt.Errorf("invalid Google Pay auth method: %s", req.AuthMethod)
+	}
+
+	var response dto.WalletPaymentResponse
+	if err := a.sendJSONRequest(ctx, "google/payment.do", dto.FromCoreGooglePay(req), &response); err != nil {
+		return core.PaymentResponse{}, err
+	}
+
+	return response.DtoToCore(), nil
+}
+
+// PayOrder — оплата зарегистрированного заказа данными карты.
+// Endpoint: `paymentorder.do`.
+// Доступно только мерчантам, сертифицированным по PCI DSS и размещающим платёжную форму у себя.
+// Перед вызовом заказ должен быть зарегистрирован через RegisterOrder или AuthOrder.
+//
+// Номер карты (по алгоритму Луна), CVC и срок действия проверяются локально до отправки.
+// Карточные данные передаются только в теле запроса и никогда не пишутся в лог.
+//
+// Возвращает PaymentResponse. Если банк-эмитент требует 3-D Secure,
+// поле ThreeDS содержит данные для перенаправления клиента на ACS.
+func (a *api) PayOrder(ctx context.Context, req core.CardPaymentRequest) (core.PaymentResponse, error) {
+	if err := validateCardPayment(req, time.Now()); err != nil {
+		return core.PaymentResponse{}, err
+	}
+
+	reqParams := dto.FromCoreCardPayment(req).ToUrlValues()
+
+	var response dto.CardPaymentResponse
+	if err := a.sendFormRequest(ctx, "paymentorder.do", reqParams, &response); err != nil {
+		return core.PaymentResponse{}, err
+	}
+
+	return response.DtoToCore(req.OrderID), nil
+}
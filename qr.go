@@ -0,0 +1,132 @@
+package bereke_merchant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsagat/bereke-merchant-api/internal/qr"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/dto"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+// ErrQRNotEnabled — методы QR-кодов вызваны без опции WithExperimentalQR.
+var ErrQRNotEnabled = errors.New("QR payments are experimental: enable them with WithExperimentalQR")
+
+// QREndpoints — пути endpoint'ов динамических QR-кодов относительно REST API шлюза.
+type QREndpoints struct {
+	Dynamic string // Выдача QR-кода по заказу
+	Status  string // Статус оплаты по QR-коду
+}
+
+// DefaultQREndpoints — пути QR-сервиса платформы шлюза (sbp/c2b/qr/...).
+// Экспериментально: это пути СБП платформы процессинга, в документации Bereke Bank
+// они не описаны. Перед использованием подтвердите у банка, что сервис подключён
+// для вашего мерчанта, и уточните его адреса.
+var DefaultQREndpoints = QREndpoints{
+	Dynamic: "sbp/c2b/qr/dynamic/get.do",
+	Status:  "sbp/c2b/qr/status.do",
+}
+
+// defaultQRImageSize — размер изображения QR-кода по умолчанию (в пикселях).
+const defaultQRImageSize = 256
+
+// RegisterQR — регистрация заказа с оплатой по динамическому QR-коду.
+// Endpoint'ы: `register.do`, затем QREndpoints.Dynamic.
+// Экспериментально: доступно только с опцией WithExperimentalQR, иначе возвращается ErrQRNotEnabled.
+// Изображение QR-кода формируется локально из полученного payload (PNG или SVG),
+// поэтому его можно сразу показать на экране киоска без платёжной формы.
+//
+// Если шлюз отклонил регистрацию заказа или выдачу QR-кода,
+// возвращается QRResponse с кодом ошибки и без изображения.
+// Если заказ уже зарегистрирован, а получить или отрисовать QR-код не удалось,
+// вместе с ошибкой возвращается QRResponse с заполненным OrderID —
+// по нему заказ можно отменить или повторно запросить QR-код.
+func (a *api) RegisterQR(ctx context.Context, req core.QRRequest) (core.QRResponse, error) {
+	if a.qr == nil {
+		return core.QRResponse{}, ErrQRNotEnabled
+	}
+
+	format := req.ImageFormat
+	if format == "" {
+		format = types.QRImagePNG
+	}
+	if format != types.QRImagePNG && format != types.QRImageSVG {
+		return core.QRResponse{}, fmt.Errorf("invalid QR image format: %s", format)
+	}
+
+	order, err := a.RegisterOrder(ctx, req.RegisterOrderRequest)
+	if err != nil {
+		return core.QRResponse{}, err
+	}
+	if order.ErrorCode != 0 || order.OrderID == "" {
+		return core.QRResponse{Response: order.Response, OrderID: order.OrderID}, nil
+	}
+
+	reqParams := dto.QRRequest{OrderID: order.OrderID, QRFormat: "matrix"}.ToUrlValues()
+
+	var response dto.QRResponse
+	if err := a.sendRequest(ctx, POST, a.qr.Dynamic, reqParams, &response); err != nil {
+		return core.QRResponse{Response: order.Response, OrderID: order.OrderID}, err
+	}
+
+	result := core.QRResponse{
+		Response: response.Response.DtoToCore(),
+		OrderID:  order.OrderID,
+		QRID:     response.QRID,
+		Payload:  response.Payload,
+	}
+	if result.ErrorCode != 0 {
+		return result, nil
+	}
+	if result.Payload == "" {
+		return result, errors.New("gateway returned empty QR payload")
+	}
+
+	image, err := renderQR(result.Payload, format, req.ImageSize)
+	if err != nil {
+		return result, err
+	}
+	result.Image = image
+	result.ImageFormat = format
+
+	return result, nil
+}
+
+// GetQRStatus — получение статуса оплаты по QR-коду.
+// Endpoint: QREndpoints.Status.
+// Статус QR-кода дополнительно переводится в types.OrderStatus.
+// Экспериментально: доступно только с опцией WithExperimentalQR.
+func (a *api) GetQRStatus(ctx context.Context, req core.QRStatusRequest) (core.QRStatusResponse, error) {
+	if a.qr == nil {
+		return core.QRStatusResponse{}, ErrQRNotEnabled
+	}
+
+	reqParams := dto.QRStatusRequest{OrderID: req.OrderID, QRID: req.QRID}.ToUrlValues()
+
+	var response dto.QRStatusResponse
+	if err := a.sendRequest(ctx, POST, a.qr.Status, reqParams, &response); err != nil {
+		return core.QRStatusResponse{}, err
+	}
+	return response.DtoToCore(), nil
+}
+
+// renderQR — локальная отрисовка QR-кода с уровнем коррекции M.
+func renderQR(payload string, format types.QRImageFormat, size int) ([]byte, error) {
+	code, err := qr.Encode([]byte(payload), qr.M)
+	if err != nil {
+		return nil, err
+	}
+
+	if size <= 0 {
+		size = defaultQRImageSize
+	}
+	// Размер модуля в пикселях с учётом светлой рамки в 4 модуля с каждой стороны
+	scale := max(1, size/(code.Size+8))
+
+	if format == types.QRImageSVG {
+		return code.SVG(scale), nil
+	}
+	return code.PNG(scale)
+}
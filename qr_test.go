@@ -0,0 +1,53 @@
+package bereke_merchant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+func TestQRRequiresExperimentalOption(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request to %s without WithExperimentalQR", r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, err := NewWithToken("token", types.TEST, WithGatewayURL(server.URL+"/payment/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.RegisterQR(context.Background(), core.QRRequest{}); !errors.Is(err, ErrQRNotEnabled) {
+		t.Errorf("RegisterQR: err = %v, want ErrQRNotEnabled", err)
+	}
+	if _, err := client.GetQRStatus(context.Background(), core.QRStatusRequest{OrderID: "order-1"}); !errors.Is(err, ErrQRNotEnabled) {
+		t.Errorf("GetQRStatus: err = %v, want ErrQRNotEnabled", err)
+	}
+}
+
+func TestQRCustomEndpoints(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"errorCode":"0","qrStatus":"STARTED"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewWithToken("token", types.TEST,
+		WithGatewayURL(server.URL+"/payment/"),
+		WithExperimentalQR(QREndpoints{Status: "qr/status.do"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetQRStatus(context.Background(), core.QRStatusRequest{OrderID: "order-1", QRID: "qr-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 || paths[0] != "/payment/rest/qr/status.do" {
+		t.Errorf("requested paths = %v, want /payment/rest/qr/status.do", paths)
+	}
+}
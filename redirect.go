@@ -0,0 +1,123 @@
+package bereke_merchant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+var (
+	// ErrNoFormURL — в ответе на регистрацию заказа нет корректного URL платёжной страницы.
+	ErrNoFormURL = errors.New("register order response has no valid payment form URL")
+
+	// ErrMissingOrderID — в запросе возврата с платёжной страницы нет параметра orderId.
+	ErrMissingOrderID = errors.New("payment return request has no orderId")
+
+	// ErrPaymentNotCompleted — заказ не оплачен: отклонён, отменён или оплата ещё не завершена.
+	ErrPaymentNotCompleted = errors.New("payment is not completed")
+)
+
+// RedirectToPayment — перенаправление покупателя на платёжную страницу (FormURL)
+// после RegisterOrder/AuthOrder ответом 303 See Other.
+// Если шлюз отклонил регистрацию или FormURL пуст, ответ не отправляется и возвращается ошибка.
+func RedirectToPayment(w http.ResponseWriter, r *http.Request, resp core.RegisterOrderResponse) error {
+	if !resp.ErrorCode.IsSuccess() {
+		return fmt.Errorf("order registration failed: errorCode=%d %s", resp.ErrorCode, resp.ErrorMessage)
+	}
+
+	u, err := url.Parse(resp.FormURL)
+	if err != nil || !u.IsAbs() || (u.Scheme != "https" && u.Scheme != "http") {
+		return ErrNoFormURL
+	}
+
+	http.Redirect(w, r, u.String(), http.StatusSeeOther)
+	return nil
+}
+
+// PaymentReturnConfig — обработчики возврата покупателя с платёжной страницы.
+type PaymentReturnConfig struct {
+	// Заказ оплачен (статус «завершён» или «авторизован» для двухстадийных платежей).
+	// status — статус, полученный из шлюза, а не из параметров запроса
+	OnSuccess func(w http.ResponseWriter, r *http.Request, status core.OrderStatusResponse)
+
+	// Заказ не оплачен или статус не удалось проверить. err — ErrMissingOrderID,
+	// ErrPaymentNotCompleted (status заполнен) или ошибка запроса статуса
+	OnFailure func(w http.ResponseWriter, r *http.Request, status core.OrderStatusResponse, err error)
+
+	// Таймаут запроса статуса (0 — без собственного таймаута)
+	Timeout time.Duration
+}
+
+// PaymentReturnHandler — HTTP-обработчик для ReturnURL/FailURL.
+//
+// Шлюз добавляет к адресу возврата параметр orderId. Обработчик не доверяет ни параметрам запроса,
+// ни тому, на какой из адресов вернулся покупатель: статус заказа всегда проверяется
+// через GetOrderStatusByID. Поэтому ReturnURL и FailURL могут указывать на один обработчик.
+//
+// Параметр orderId может подставить сам покупатель, поэтому в OnSuccess сверяйте
+// status.OrderNumber и сумму с заказом из сессии пользователя.
+//
+// Если OnSuccess или OnFailure не заданы, отвечает простым текстом (200 или 402 Payment Required).
+func PaymentReturnHandler(api API, cfg PaymentReturnConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, err := verifyPaymentReturn(r, api, cfg.Timeout)
+		if err != nil {
+			if cfg.OnFailure != nil {
+				cfg.OnFailure(w, r, status, err)
+				return
+			}
+			http.Error(w, "Оплата не завершена", http.StatusPaymentRequired)
+			return
+		}
+
+		if cfg.OnSuccess != nil {
+			cfg.OnSuccess(w, r, status)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintln(w, "Оплата прошла успешно")
+	})
+}
+
+// verifyPaymentReturn — проверка статуса заказа из запроса возврата с платёжной страницы.
+func verifyPaymentReturn(r *http.Request, api API, timeout time.Duration) (core.OrderStatusResponse, error) {
+	query := r.URL.Query()
+	orderID := query.Get("orderId")
+	if orderID == "" {
+		orderID = query.Get("mdOrder")
+	}
+	if orderID == "" {
+		return core.OrderStatusResponse{}, ErrMissingOrderID
+	}
+
+	ctx := r.Context()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	status, err := api.GetOrderStatusByID(ctx, orderID)
+	if err != nil {
+		return status, err
+	}
+	if !status.ErrorCode.IsSuccess() {
+		return status, fmt.Errorf("order status: errorCode=%d %s", status.ErrorCode, status.ErrorMessage)
+	}
+	if status.OrderID == "" {
+		status.OrderID = orderID
+	}
+
+	switch status.OrderStatus {
+	case types.OrderStatusCompleted, types.OrderStatusAuthorized:
+		return status, nil
+	default:
+		return status, fmt.Errorf("%w: orderStatus=%d actionCode=%d", ErrPaymentNotCompleted, status.OrderStatus, status.ActionCode)
+	}
+}
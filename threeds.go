@@ -0,0 +1,151 @@
+package bereke_merchant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/dto"
+)
+
+// VerifyEnrollment — проверка вовлечённости карты в 3-D Secure.
+// Endpoint: `verifyEnrollment.do`.
+// Номер карты проверяется локально по алгоритму Луна и передаётся только в теле запроса.
+func (a *api) VerifyEnrollment(ctx context.Context, req core.EnrollmentRequest) (core.EnrollmentResponse, error) {
+	if !isDigits(req.PAN) || len(req.PAN) < 12 || len(req.PAN) > 19 || !luhnValid(req.PAN) {
+		return core.EnrollmentResponse{}, ErrInvalidPAN
+	}
+
+	reqParams := dto.EnrollmentRequest{PAN: req.PAN}.ToUrlValues()
+
+	var response dto.EnrollmentResponse
+	if err := a.sendFormRequest(ctx, "verifyEnrollment.do", reqParams, &response); err != nil {
+		return core.EnrollmentResponse{}, err
+	}
+	return response.DtoToCore(), nil
+}
+
+// ContinueThreeDS2 — продолжение оплаты по 3-D Secure 2.
+// Endpoint: `paymentorder.do` (повторный вызов).
+//
+// Последовательность 3DS2 для host-to-host оплаты:
+//  1. PayOrder — шлюз возвращает ThreeDSChallenge с Is3DSVer2 = true и ThreeDSServerTransID;
+//  2. если указан ThreeDSMethodURL — отрисовать RenderThreeDSMethodForm в скрытом iframe;
+//  3. ContinueThreeDS2 — передать ThreeDSServerTransID и данные браузера клиента;
+//  4. если шлюз вернул ACSUrl — перенаправить клиента через RenderACSForm (challenge);
+//  5. после возврата клиента на FinishURL — вызвать FinishThreeDS.
+//
+// Если challenge не требуется (frictionless), оплата завершается на шаге 3.
+func (a *api) ContinueThreeDS2(ctx context.Context, req core.ThreeDS2Request) (core.PaymentResponse, error) {
+	if req.ThreeDSServerTransID == "" {
+		return core.PaymentResponse{}, errors.New("3DS server transaction ID is required")
+	}
+	if err := validateCardPayment(req.CardPaymentRequest, time.Now()); err != nil {
+		return core.PaymentResponse{}, err
+	}
+
+	reqParams := dto.FromCoreThreeDS2(req).ToUrlValues()
+
+	var response dto.CardPaymentResponse
+	if err := a.sendFormRequest(ctx, "paymentorder.do", reqParams, &response); err != nil {
+		return core.PaymentResponse{}, err
+	}
+	return response.DtoToCore(req.OrderID), nil
+}
+
+// FinishThreeDS — завершение аутентификации 3-D Secure 2 после возврата клиента с ACS.
+// Endpoint: `finishThreeDs.do`.
+// Возвращает PaymentResponse с результатом оплаты.
+func (a *api) FinishThreeDS(ctx context.Context, req core.FinishThreeDSRequest) (core.PaymentResponse, error) {
+	if req.ThreeDSServerTransID == "" {
+		return core.PaymentResponse{}, errors.New("3DS server transaction ID is required")
+	}
+
+	reqParams := dto.FromCoreFinishThreeDS(req).ToUrlValues()
+
+	var response dto.CardPaymentResponse
+	if err := a.sendRequest(ctx, POST, "finishThreeDs.do", reqParams, &response); err != nil {
+		return core.PaymentResponse{}, err
+	}
+	return response.DtoToCore(req.OrderID), nil
+}
+
+// autoSubmitForm — HTML-страница с формой, которая автоматически отправляется при загрузке.
+// Для браузеров без JavaScript отображается кнопка "Продолжить".
+var autoSubmitForm = template.Must(template.New("form").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>3-D Secure</title></head>
+<body onload="document.forms[0].submit()">
+<form method="POST" action="{{.Action}}">
+{{- range $name, $value := .Fields}}
+<input type="hidden" name="{{$name}}" value="{{$value}}">
+{{- end}}
+<noscript><button type="submit">Продолжить</button></noscript>
+</form>
+</body>
+</html>
+`))
+
+// RenderACSForm — отрисовывает HTML-страницу, автоматически перенаправляющую клиента на ACS банка-эмитента.
+// Для 3DS2 в ACS передаётся creq, для 3DS1 — PaReq, MD и TermUrl.
+// Ответ можно записать напрямую в http.ResponseWriter.
+func RenderACSForm(w io.Writer, challenge core.ThreeDSChallenge) error {
+	if challenge.ACSUrl == "" {
+		return errors.New("ACS URL is empty")
+	}
+
+	fields := map[string]string{}
+	if challenge.PackedCReq != "" {
+		fields["creq"] = challenge.PackedCReq
+	} else {
+		fields["PaReq"] = challenge.PaReq
+		fields["MD"] = challenge.MD
+		fields["TermUrl"] = challenge.TermURL
+	}
+
+	action, err := formAction(challenge.ACSUrl)
+	if err != nil {
+		return fmt.Errorf("ACS URL: %w", err)
+	}
+	return renderForm(w, action, fields)
+}
+
+// RenderThreeDSMethodForm — отрисовывает форму 3DS Method для загрузки в скрытом iframe.
+// Эмитент собирает данные устройства и уведомляет MethodNotificationURL о завершении.
+func RenderThreeDSMethodForm(w io.Writer, challenge core.ThreeDSChallenge) error {
+	if challenge.ThreeDSMethodURL == "" {
+		return errors.New("3DS method URL is empty")
+	}
+
+	action, err := formAction(challenge.ThreeDSMethodURL)
+	if err != nil {
+		return fmt.Errorf("3DS method URL: %w", err)
+	}
+	return renderForm(w, action, map[string]string{"threeDSMethodData": challenge.ThreeDSMethodData})
+}
+
+// formAction — проверка адреса формы из ответа шлюза: только абсолютный http(s) URL.
+// Адрес передаётся в шаблон обычной строкой, поэтому html/template экранирует его
+// как любой другой атрибут URL, а javascript: и прочие схемы отклоняются до отрисовки.
+func formAction(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return "", fmt.Errorf("%q is not an absolute http(s) URL", raw)
+	}
+	return u.String(), nil
+}
+
+func renderForm(w io.Writer, action string, fields map[string]string) error {
+	return autoSubmitForm.Execute(w, struct {
+		Action string
+		Fields map[string]string
+	}{action, fields})
+}
@@ -0,0 +1,49 @@
+package bereke_merchant
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bsagat/bereke-merchant-api/models/core"
+)
+
+func TestRenderACSForm(t *testing.T) {
+	var page strings.Builder
+	err := RenderACSForm(&page, core.ThreeDSChallenge{
+		ACSUrl:     "https://acs.example.com/challenge?session=1&lang=ru",
+		PackedCReq: "eyJ0aHJlZURT",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`action="https://acs.example.com/challenge?session=1&amp;lang=ru"`,
+		`name="creq" value="eyJ0aHJlZURT"`,
+	} {
+		if !strings.Contains(page.String(), want) {
+			t.Errorf("page does not contain %s:\n%s", want, page.String())
+		}
+	}
+}
+
+func TestRenderFormRejectsUnsafeURL(t *testing.T) {
+	for _, raw := range []string{
+		"javascript:alert(1)",
+		"data:text/html,<script>alert(1)</script>",
+		"//acs.example.com/challenge",
+		"/challenge",
+		"https://",
+	} {
+		var page strings.Builder
+		if err := RenderACSForm(&page, core.ThreeDSChallenge{ACSUrl: raw, PackedCReq: "creq"}); err == nil {
+			t.Errorf("RenderACSForm(%q) accepted an unsafe URL", raw)
+		}
+		if err := RenderThreeDSMethodForm(&page, core.ThreeDSChallenge{ThreeDSMethodURL: raw}); err == nil {
+			t.Errorf("RenderThreeDSMethodForm(%q) accepted an unsafe URL", raw)
+		}
+		if page.Len() != 0 {
+			t.Errorf("page rendered for %q", raw)
+		}
+	}
+}
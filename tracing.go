@@ -0,0 +1,105 @@
+package bereke_merchant
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	money "github.com/bsagat/bereke-merchant-api/currency"
+	"github.com/bsagat/bereke-merchant-api/models/code"
+)
+
+// Tracer — интеграция с системой распределённой трассировки.
+// На каждый запрос к шлюзу создаётся span с именем endpoint (например, "register.do"),
+// дочерний по отношению к span'у из ctx вызывающего метода.
+// Готовый адаптер для OpenTelemetry: модуль github.com/bsagat/bereke-merchant-api/tracing/otel.
+type Tracer interface {
+	// Start — начало span'а; возвращает ctx, содержащий новый span.
+	Start(ctx context.Context, endpoint string) (context.Context, Span)
+}
+
+// Span — span запроса к шлюзу.
+type Span interface {
+	// End — завершение span'а с атрибутами запроса и ошибкой (если есть).
+	End(attrs SpanAttributes, err error)
+}
+
+// SpanAttributes — атрибуты span'а запроса. Карточные данные сюда не попадают.
+type SpanAttributes struct {
+	OrderNumber string         // Номер заказа в системе мерчанта
+	OrderID     string         // ID заказа в платёжном шлюзе (из запроса или ответа)
+	Amount      float64        // Сумма в основных единицах валюты
+	Currency    int            // Код валюты (ISO 4217)
+	HTTPStatus  int            // HTTP-статус ответа (0 — ответ не получен)
+	ErrorCode   code.ErrorCode // Код ошибки шлюза
+}
+
+// WithTracer — подключение трассировки к клиенту.
+func WithTracer(tracer Tracer) Option {
+	return func(a *api) {
+		a.tracer = tracer
+	}
+}
+
+// startSpan — начало span'а, если трассировка подключена; иначе возвращает исходный ctx и nil.
+func (a *api) startSpan(ctx context.Context, endpoint string) (context.Context, Span) {
+	if a.tracer == nil {
+		return ctx, nil
+	}
+	return a.tracer.Start(ctx, endpoint)
+}
+
+// paramsAttributes — атрибуты span'а из параметров запроса (url.Values).
+func paramsAttributes(params url.Values) SpanAttributes {
+	attrs := SpanAttributes{
+		OrderNumber: params.Get("orderNumber"),
+		OrderID:     params.Get("orderId"),
+	}
+	if attrs.OrderID == "" {
+		attrs.OrderID = firstNonEmpty(params.Get("MDORDER"), params.Get("mdOrder"))
+	}
+
+	attrs.Currency, _ = strconv.Atoi(params.Get("currency"))
+	if minor, err := strconv.Atoi(params.Get("amount")); err == nil {
+		attrs.Amount = money.ConvertFromMinorUnits(minor, attrs.Currency)
+	}
+	return attrs
+}
+
+// payloadAttributes — атрибуты span'а из JSON-тела запроса.
+func payloadAttributes(payload []byte) SpanAttributes {
+	var body struct {
+		OrderNumber  string `json:"orderNumber"`
+		Amount       int    `json:"amount"`
+		CurrencyCode int    `json:"currencyCode"`
+	}
+	_ = json.Unmarshal(payload, &body)
+
+	return SpanAttributes{
+		OrderNumber: body.OrderNumber,
+		Amount:      money.ConvertFromMinorUnits(body.Amount, body.CurrencyCode),
+		Currency:    body.CurrencyCode,
+	}
+}
+
+// orderIDer — ответ шлюза с ID заказа.
+type orderIDer interface {
+	GatewayOrderID() string
+}
+
+func gatewayOrderID(result interface{}) string {
+	if o, ok := result.(orderIDer); ok {
+		return o.GatewayOrderID()
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
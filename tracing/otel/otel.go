@@ -0,0 +1,76 @@
+// Package otel — адаптер трассировки запросов к Bereke Merchant API для OpenTelemetry.
+//
+//	tracer := otel.New(otelglobal.GetTracerProvider())
+//	api, err := bereke_merchant.NewWithLogin(login, password, types.PROD, bereke_merchant.WithTracer(tracer))
+package otel
+
+import (
+	"context"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+)
+
+// instrumentationName — имя библиотеки инструментирования.
+const instrumentationName = "github.com/bsagat/bereke-merchant-api"
+
+// Tracer — реализация bereke.Tracer поверх OpenTelemetry Trace.
+//
+// Span'ы имеют тип client и имя endpoint. Атрибуты: bereke.order_number, bereke.order_id,
+// bereke.amount, bereke.currency, http.response.status_code, bereke.error_code.
+// Ненулевой код ошибки шлюза выставляет статус span'а Error.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+var _ bereke.Tracer = (*Tracer)(nil)
+
+// New — создание адаптера на основе провайдера.
+func New(provider trace.TracerProvider) *Tracer {
+	return &Tracer{tracer: provider.Tracer(instrumentationName)}
+}
+
+// Start — начало span'а запроса к шлюзу.
+func (t *Tracer) Start(ctx context.Context, endpoint string) (context.Context, bereke.Span) {
+	ctx, span := t.tracer.Start(ctx, endpoint, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, otelSpan{span: span}
+}
+
+type otelSpan struct {
+	span trace.Span
+}
+
+// End — запись атрибутов и статуса, завершение span'а.
+func (s otelSpan) End(attrs bereke.SpanAttributes, err error) {
+	kv := make([]attribute.KeyValue, 0, 6)
+	if attrs.OrderNumber != "" {
+		kv = append(kv, attribute.String("bereke.order_number", attrs.OrderNumber))
+	}
+	if attrs.OrderID != "" {
+		kv = append(kv, attribute.String("bereke.order_id", attrs.OrderID))
+	}
+	if attrs.Amount != 0 {
+		kv = append(kv, attribute.Float64("bereke.amount", attrs.Amount))
+	}
+	if attrs.Currency != 0 {
+		kv = append(kv, attribute.Int("bereke.currency", attrs.Currency))
+	}
+	if attrs.HTTPStatus != 0 {
+		kv = append(kv, attribute.Int("http.response.status_code", attrs.HTTPStatus))
+	}
+	kv = append(kv, attribute.Int("bereke.error_code", int(attrs.ErrorCode)))
+	s.span.SetAttributes(kv...)
+
+	switch {
+	case err != nil:
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	case attrs.ErrorCode != 0:
+		s.span.SetStatus(codes.Error, "gateway error code "+strconv.Itoa(int(attrs.ErrorCode)))
+	}
+	s.span.End()
+}
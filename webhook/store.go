@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+// Key — ключ дедупликации колбэка: заказ, операция, результат и сумма.
+// Повторная доставка колбэка с тем же ключом считается дублем.
+//
+// Для успешных возвратов Amount — общая возвращённая сумма заказа по GetOrderStatus,
+// а не сумма из колбэка: новый возврат подтверждается ростом этой суммы, поэтому два
+// частичных возврата на одинаковую сумму дают разные ключи.
+type Key struct {
+	OrderID   string                  // ID заказа в шлюзе (mdOrder)
+	Operation types.CallbackOperation // Тип операции
+	Success   bool                    // Результат операции (status)
+	Amount    int64                   // Сумма в минорных единицах (0 — шлюз не передал amount)
+}
+
+// DedupStore — хранилище обработанных колбэков.
+// Реализация должна быть безопасна для конкурентного использования; для нескольких
+// инстансов сервиса используйте общее хранилище (Redis, таблица в БД).
+type DedupStore interface {
+	// Seen — колбэк с таким ключом уже обработан.
+	Seen(ctx context.Context, key Key) (bool, error)
+
+	// MarkDone — отметить колбэк обработанным. Вызывается только после успешной обработки.
+	MarkDone(ctx context.Context, key Key) error
+}
+
+// DefaultDedupTTL — время хранения обработанных колбэков в MemoryStore по умолчанию.
+// Шлюз повторяет доставку колбэка в течение нескольких часов, более старые ключи не нужны.
+const DefaultDedupTTL = 72 * time.Hour
+
+// MemoryStore — хранилище в памяти процесса.
+// Подходит для тестов и одиночных инстансов без требований к переживанию рестарта.
+// Ключи хранятся ttl и удаляются при последующих вызовах MarkDone.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	ttl       time.Duration
+	done      map[Key]time.Time
+	lastPrune time.Time
+}
+
+var _ DedupStore = (*MemoryStore)(nil)
+
+// NewMemoryStore — создание хранилища в памяти; ttl <= 0 — DefaultDedupTTL.
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	if ttl <= 0 {
+		ttl = DefaultDedupTTL
+	}
+	return &MemoryStore{ttl: ttl, done: map[Key]time.Time{}, lastPrune: time.Now()}
+}
+
+// Seen — колбэк уже обработан (и ключ ещё не устарел).
+func (s *MemoryStore) Seen(_ context.Context, key Key) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	at, ok := s.done[key]
+	return ok && time.Since(at) < s.ttl, nil
+}
+
+// MarkDone — отметить колбэк обработанным.
+func (s *MemoryStore) MarkDone(_ context.Context, key Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.done[key] = now
+	s.prune(now)
+	return nil
+}
+
+// prune — удаление устаревших ключей не чаще раза в ttl/2. Вызывается под mu.
+func (s *MemoryStore) prune(now time.Time) {
+	if now.Sub(s.lastPrune) < s.ttl/2 {
+		return
+	}
+	s.lastPrune = now
+	for key, at := range s.done {
+		if now.Sub(at) >= s.ttl {
+			delete(s.done, key)
+		}
+	}
+}
@@ -0,0 +1,352 @@
+// Package webhook — обработчик колбэков платёжного шлюза с дедупликацией
+// и защитой от нарушения порядка доставки.
+//
+// Шлюз доставляет колбэки «как минимум один раз»: один и тот же колбэк может прийти
+// несколько раз, а колбэки по одному заказу — в другом порядке (deposited раньше approved).
+// Handler:
+//   - проверяет контрольную сумму (HMAC-SHA256 или RSA SHA-256), если задан ключ;
+//   - пропускает уже обработанные колбэки по ключу (mdOrder, operation, status, amount) из DedupStore;
+//     успешный возврат считается новым, только если выросла общая возвращённая сумма заказа;
+//   - запрашивает актуальный статус заказа через GetOrderStatus и не передаёт колбэк дальше,
+//     пока статус в шлюзе не подтверждает операцию;
+//   - вызывает функцию мерчанта с повторами и отмечает колбэк обработанным только после успеха;
+//   - отвечает 200 OK при успехе или дубле и кодом 5xx при сбое, чтобы шлюз повторил доставку.
+//
+// Решения принимайте по Event.Order.OrderStatus (актуальный статус), а не по Event.Operation:
+// устаревший колбэк approved, пришедший после deposited, доставляется с текущим статусом заказа.
+//
+//	handler := webhook.New(api, func(ctx context.Context, event webhook.Event) error {
+//		return orders.Sync(ctx, event.OrderNumber, event.Order.OrderStatus)
+//	}, webhook.Config{HMACKey: os.Getenv("BEREKE_CALLBACK_KEY")})
+//	http.Handle("/bereke/callback", handler)
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+	"github.com/bsagat/bereke-merchant-api/internal/sign"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+var (
+	// ErrInvalidCallback — в колбэке нет обязательных параметров (mdOrder, operation, status)
+	// или параметр имеет некорректное значение.
+	ErrInvalidCallback = errors.New("invalid callback parameters")
+
+	// ErrInvalidChecksum — контрольная сумма колбэка не совпадает.
+	ErrInvalidChecksum = errors.New("invalid callback checksum")
+
+	// ErrNotConfirmed — статус заказа в шлюзе пока не подтверждает операцию из колбэка.
+	ErrNotConfirmed = errors.New("callback operation is not confirmed by order status")
+)
+
+// Event — колбэк, подтверждённый актуальным статусом заказа.
+type Event struct {
+	OrderID     string                  // ID заказа в шлюзе (mdOrder)
+	OrderNumber string                  // Номер заказа в системе мерчанта
+	Operation   types.CallbackOperation // Тип операции
+	Success     bool                    // Результат операции (status = 1)
+	Amount      int64                   // Сумма операции в минорных единицах (0 — шлюз не передал amount)
+	Params      url.Values              // Все параметры колбэка
+
+	Order core.OrderStatusResponse // Актуальный статус заказа (GetOrderStatus)
+}
+
+// Key — ключ дедупликации события. Для успешного возврата ключ зависит от Order
+// (общая возвращённая сумма) и определён только после запроса статуса заказа.
+func (e Event) Key() Key {
+	key := Key{OrderID: e.OrderID, Operation: e.Operation, Success: e.Success, Amount: e.Amount}
+	if refund(e) {
+		key.Amount = e.Order.PaymentAmountInfo.RefundedAmount
+	}
+	return key
+}
+
+// refund — успешный возврат: сумма из колбэка не отличает два возврата на одинаковую сумму,
+// поэтому дубль определяется по общей возвращённой сумме заказа.
+func refund(e Event) bool {
+	return e.Operation == types.CallbackRefunded && e.Success
+}
+
+// HandlerFunc — обработка колбэка мерчантом. Ошибка означает, что колбэк нужно доставить повторно.
+// Функция может быть вызвана несколько раз для одного колбэка и должна быть идемпотентной.
+type HandlerFunc func(ctx context.Context, event Event) error
+
+// Config — параметры обработчика колбэков.
+type Config struct {
+	// Хранилище обработанных колбэков (по умолчанию NewMemoryStore(DefaultDedupTTL))
+	Store DedupStore
+
+	// Секрет для проверки симметричной контрольной суммы (HMAC-SHA256)
+	HMACKey string
+
+	// Открытый ключ для проверки асимметричной контрольной суммы (RSA SHA-256).
+	// Если не заданы ни HMACKey, ни PublicKey, контрольная сумма не проверяется
+	PublicKey *rsa.PublicKey
+
+	// Количество вызовов HandlerFunc до ответа шлюзу ошибкой (по умолчанию 3)
+	MaxAttempts int
+
+	// Пауза перед повторным вызовом HandlerFunc, удваивается с каждой попыткой (по умолчанию 200 мс)
+	RetryDelay time.Duration
+
+	// Вызывается перед ответом шлюзу кодом, отличным от 200 (необязательно):
+	// status — HTTP-код ответа, err — причина. Используйте для логирования и метрик
+	OnError func(r *http.Request, status int, err error)
+}
+
+// Handler — http.Handler для колбэков шлюза.
+type Handler struct {
+	api bereke.API
+	fn  HandlerFunc
+	cfg Config
+
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// New — создание обработчика колбэков поверх API клиента.
+func New(api bereke.API, fn HandlerFunc, cfg Config) *Handler {
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryStore(DefaultDedupTTL)
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 3
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = 200 * time.Millisecond
+	}
+	return &Handler{api: api, fn: fn, cfg: cfg, locks: map[string]*keyLock{}}
+}
+
+// ServeHTTP — приём колбэка (GET с параметрами в URL или POST с формой).
+//
+// Коды ответа:
+//   - 200 — колбэк обработан или является дублем;
+//   - 400 — нет обязательных параметров, 403 — неверная контрольная сумма (повтор не поможет);
+//   - 503 — статус заказа не получен или ещё не подтверждает операцию;
+//   - 500 — HandlerFunc или DedupStore вернули ошибку.
+//
+// На любой ответ, кроме 200, шлюз повторяет доставку.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status, err := h.handle(r.Context(), r)
+	if err != nil {
+		if h.cfg.OnError != nil {
+			h.cfg.OnError(r, status, err)
+		}
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "OK")
+}
+
+// handle — обработка колбэка; возвращает HTTP-код ответа шлюзу и ошибку (nil — 200 OK).
+func (h *Handler) handle(ctx context.Context, r *http.Request) (int, error) {
+	if err := r.ParseForm(); err != nil {
+		return http.StatusBadRequest, err
+	}
+	values := r.Form
+
+	event, err := parse(values)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if err := h.verify(values); err != nil {
+		return http.StatusForbidden, fmt.Errorf("%s: %w", event.OrderID, err)
+	}
+
+	// Колбэки по одному заказу обрабатываются последовательно
+	unlock := h.lock(event.OrderID)
+	defer unlock()
+
+	// Ключ возврата известен только после запроса статуса заказа
+	if !refund(event) {
+		if seen, err := h.cfg.Store.Seen(ctx, event.Key()); err != nil || seen {
+			return dedupStatus(err)
+		}
+	}
+
+	event.Order, err = h.api.GetOrderStatus(ctx, core.OrderStatusRequest{OrderID: event.OrderID})
+	if err != nil {
+		return http.StatusServiceUnavailable, fmt.Errorf("%s: order status: %w", event.OrderID, err)
+	}
+	if !event.Order.ErrorCode.IsSuccess() {
+		return http.StatusServiceUnavailable, fmt.Errorf("%s: order status: errorCode=%d %s",
+			event.OrderID, event.Order.ErrorCode, event.Order.ErrorMessage)
+	}
+	if event.Success && !confirmed(event.Operation, event.Order) {
+		return http.StatusServiceUnavailable, fmt.Errorf("%s %s: %w (orderStatus=%d)",
+			event.OrderID, event.Operation, ErrNotConfirmed, event.Order.OrderStatus)
+	}
+
+	key := event.Key()
+	if refund(event) {
+		if seen, err := h.cfg.Store.Seen(ctx, key); err != nil || seen {
+			return dedupStatus(err)
+		}
+	}
+
+	if err := h.deliver(ctx, event); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("%s %s: %w", event.OrderID, event.Operation, err)
+	}
+	if err := h.cfg.Store.MarkDone(ctx, key); err != nil {
+		// Колбэк обработан, но не отмечен: повторная доставка вызовет HandlerFunc ещё раз
+		return http.StatusInternalServerError, fmt.Errorf("dedup store: %w", err)
+	}
+	return http.StatusOK, nil
+}
+
+// dedupStatus — ответ на дубль (200 OK) или на ошибку DedupStore.
+func dedupStatus(err error) (int, error) {
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("dedup store: %w", err)
+	}
+	return http.StatusOK, nil
+}
+
+// deliver — вызов HandlerFunc с повторами и экспоненциальной паузой.
+func (h *Handler) deliver(ctx context.Context, event Event) error {
+	delay := h.cfg.RetryDelay
+	var err error
+	for attempt := 1; attempt <= h.cfg.MaxAttempts; attempt++ {
+		if err = h.fn(ctx, event); err == nil {
+			return nil
+		}
+		if attempt == h.cfg.MaxAttempts {
+			break
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+	return err
+}
+
+// verify — проверка контрольной суммы колбэка.
+func (h *Handler) verify(values url.Values) error {
+	checksum := values.Get(sign.ChecksumParam)
+	data := sign.CallbackString(values)
+
+	switch {
+	case h.cfg.HMACKey != "":
+		expected := sign.HMACSHA256(h.cfg.HMACKey, data)
+		if !hmac.Equal([]byte(strings.ToUpper(checksum)), []byte(expected)) {
+			return ErrInvalidChecksum
+		}
+	case h.cfg.PublicKey != nil:
+		signature, err := hex.DecodeString(checksum)
+		if err != nil || sign.VerifyDigest(h.cfg.PublicKey, sign.SHA256(data), signature) != nil {
+			return ErrInvalidChecksum
+		}
+	}
+	return nil
+}
+
+// parse — разбор параметров колбэка.
+func parse(values url.Values) (Event, error) {
+	event := Event{
+		OrderID:     values.Get("mdOrder"),
+		OrderNumber: values.Get("orderNumber"),
+		Operation:   types.CallbackOperation(values.Get("operation")),
+		Params:      values,
+	}
+
+	switch status := values.Get("status"); status {
+	case "1":
+		event.Success = true
+	case "0":
+	default:
+		return Event{}, fmt.Errorf("%w: status %q", ErrInvalidCallback, status)
+	}
+	if event.OrderID == "" || event.Operation == "" {
+		return Event{}, fmt.Errorf("%w: mdOrder and operation are required", ErrInvalidCallback)
+	}
+	if amount := values.Get("amount"); amount != "" {
+		n, err := strconv.ParseInt(amount, 10, 64)
+		if err != nil || n < 0 {
+			return Event{}, fmt.Errorf("%w: amount %q", ErrInvalidCallback, amount)
+		}
+		event.Amount = n
+	}
+	return event, nil
+}
+
+// confirmed — статус заказа достиг состояния успешной операции или прошёл дальше
+// (например, approved подтверждается и статусом «завершён», если колбэк пришёл после deposited).
+// Неизвестные операции не проверяются.
+func confirmed(operation types.CallbackOperation, order core.OrderStatusResponse) bool {
+	switch operation {
+	case types.CallbackApproved:
+		return oneOf(order.OrderStatus, types.OrderStatusAuthorized, types.OrderStatusCompleted,
+			types.OrderStatusPartial, types.OrderStatusCancelled, types.OrderStatusRefunded)
+	case types.CallbackDeposited:
+		return oneOf(order.OrderStatus, types.OrderStatusCompleted, types.OrderStatusPartial, types.OrderStatusRefunded)
+	case types.CallbackReversed:
+		return order.OrderStatus == types.OrderStatusCancelled
+	case types.CallbackRefunded:
+		return order.OrderStatus == types.OrderStatusRefunded || order.PaymentAmountInfo.RefundedAmount > 0
+	case types.CallbackDeclinedByTimeout:
+		return order.OrderStatus == types.OrderStatusDeclined
+	default:
+		return true
+	}
+}
+
+func oneOf(status types.OrderStatus, statuses ...types.OrderStatus) bool {
+	for _, s := range statuses {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
+
+// lock — блокировка по ID заказа: колбэки одного заказа выполняются последовательно,
+// разных заказов — независимо.
+func (h *Handler) lock(orderID string) func() {
+	h.mu.Lock()
+	l, ok := h.locks[orderID]
+	if !ok {
+		l = &keyLock{}
+		h.locks[orderID] = l
+	}
+	l.refs++
+	h.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+
+		h.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(h.locks, orderID)
+		}
+		h.mu.Unlock()
+	}
+}
@@ -0,0 +1,272 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	bereke "github.com/bsagat/bereke-merchant-api"
+	"github.com/bsagat/bereke-merchant-api/bereketest/callback"
+	"github.com/bsagat/bereke-merchant-api/models/core"
+	"github.com/bsagat/bereke-merchant-api/models/types"
+)
+
+type fakeAPI struct {
+	bereke.API
+
+	mu     sync.Mutex
+	status core.OrderStatusResponse
+}
+
+func (f *fakeAPI) GetOrderStatus(context.Context, core.OrderStatusRequest) (core.OrderStatusResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.status, nil
+}
+
+func (f *fakeAPI) set(status types.OrderStatus, refunded int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status = core.OrderStatusResponse{
+		OrderStatus:       status,
+		PaymentAmountInfo: core.PaymentAmountInfo{DepositedAmount: 100000, RefundedAmount: refunded},
+	}
+}
+
+// recorder — HandlerFunc, запоминающая доставленные события.
+type recorder struct {
+	mu     sync.Mutex
+	events []Event
+	err    error
+}
+
+func (r *recorder) handle(_ context.Context, event Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return r.err
+}
+
+func (r *recorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+func send(t *testing.T, h http.Handler, params url.Values) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/callback?"+params.Encode(), nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func values(op callback.Operation, amount int) url.Values {
+	return callback.Callback{OrderID: "order-1", OrderNumber: "A-1", Operation: op, Success: true, Amount: amount}.Values()
+}
+
+func TestDuplicateCallback(t *testing.T) {
+	api := &fakeAPI{}
+	api.set(types.OrderStatusCompleted, 0)
+	rec := &recorder{}
+	h := New(api, rec.handle, Config{})
+
+	for i := 0; i < 2; i++ {
+		if code := send(t, h, values(callback.Deposited, 100000)); code != http.StatusOK {
+			t.Fatalf("delivery %d: status = %d, want 200", i+1, code)
+		}
+	}
+	if got := rec.count(); got != 1 {
+		t.Errorf("handler calls = %d, want 1", got)
+	}
+}
+
+func TestEqualPartialRefunds(t *testing.T) {
+	api := &fakeAPI{}
+	rec := &recorder{}
+	h := New(api, rec.handle, Config{})
+
+	// Первый возврат на 500 и его повторная доставка
+	api.set(types.OrderStatusCompleted, 50000)
+	for i := 0; i < 2; i++ {
+		if code := send(t, h, values(callback.Refunded, 50000)); code != http.StatusOK {
+			t.Fatalf("refund 1, delivery %d: status = %d, want 200", i+1, code)
+		}
+	}
+
+	// Второй возврат на ту же сумму — новое событие, а не дубль
+	api.set(types.OrderStatusCompleted, 100000)
+	if code := send(t, h, values(callback.Refunded, 50000)); code != http.StatusOK {
+		t.Fatalf("refund 2: status = %d, want 200", code)
+	}
+	// Запоздалый повтор первого колбэка после второго возврата
+	if code := send(t, h, values(callback.Refunded, 50000)); code != http.StatusOK {
+		t.Fatalf("late redelivery: status = %d, want 200", code)
+	}
+
+	if got := rec.count(); got != 2 {
+		t.Fatalf("handler calls = %d, want 2", got)
+	}
+	for i, want := range []int64{50000, 100000} {
+		if got := rec.events[i].Order.PaymentAmountInfo.RefundedAmount; got != want {
+			t.Errorf("refund %d: refunded amount = %d, want %d", i+1, got, want)
+		}
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cb := callback.Callback{OrderID: "order-1", OrderNumber: "A-1", Operation: callback.Deposited, Success: true, Amount: 100000}
+	tests := []struct {
+		name   string
+		cfg    Config
+		signer callback.Signer
+		tamper bool
+		want   int
+	}{
+		{name: "hmac", cfg: Config{HMACKey: "secret"}, signer: callback.HMAC("secret"), want: http.StatusOK},
+		{name: "hmac wrong key", cfg: Config{HMACKey: "secret"}, signer: callback.HMAC("other"), want: http.StatusForbidden},
+		{name: "hmac tampered", cfg: Config{HMACKey: "secret"}, signer: callback.HMAC("secret"), tamper: true, want: http.StatusForbidden},
+		{name: "rsa", cfg: Config{PublicKey: &key.PublicKey}, signer: callback.RSA(key, "test"), want: http.StatusOK},
+		{name: "rsa wrong key", cfg: Config{PublicKey: &key.PublicKey}, signer: callback.RSA(other, "test"), want: http.StatusForbidden},
+		{name: "rsa tampered", cfg: Config{PublicKey: &key.PublicKey}, signer: callback.RSA(key, "test"), tamper: true, want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeAPI{}
+			api.set(types.OrderStatusCompleted, 0)
+			rec := &recorder{}
+			h := New(api, rec.handle, tt.cfg)
+
+			params, err := cb.Sign(tt.signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.tamper {
+				params.Set("amount", "1")
+			}
+			if code := send(t, h, params); code != tt.want {
+				t.Fatalf("status = %d, want %d", code, tt.want)
+			}
+			if tt.want != http.StatusOK && rec.count() != 0 {
+				t.Error("handler called for a callback with invalid checksum")
+			}
+		})
+	}
+}
+
+func TestUnconfirmedOperation(t *testing.T) {
+	// deposited пришёл раньше, чем шлюз перевёл заказ в «завершён»
+	api := &fakeAPI{}
+	api.set(types.OrderStatusAuthorized, 0)
+	rec := &recorder{}
+	h := New(api, rec.handle, Config{})
+
+	if code := send(t, h, values(callback.Deposited, 100000)); code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", code)
+	}
+	if rec.count() != 0 {
+		t.Fatal("handler called for an unconfirmed operation")
+	}
+
+	// approved подтверждается и доставляется, deposited — после смены статуса
+	if code := send(t, h, values(callback.Approved, 100000)); code != http.StatusOK {
+		t.Fatalf("approved: status = %d, want 200", code)
+	}
+	api.set(types.OrderStatusCompleted, 0)
+	if code := send(t, h, values(callback.Deposited, 100000)); code != http.StatusOK {
+		t.Fatalf("deposited redelivery: status = %d, want 200", code)
+	}
+	if rec.count() != 2 || rec.events[1].Operation != callback.Deposited {
+		t.Errorf("delivered %d events, want approved then deposited", rec.count())
+	}
+}
+
+func TestHandlerFailureIsRedelivered(t *testing.T) {
+	api := &fakeAPI{}
+	api.set(types.OrderStatusCompleted, 0)
+	rec := &recorder{err: errors.New("database is down")}
+	store := NewMemoryStore(0)
+	h := New(api, rec.handle, Config{Store: store, MaxAttempts: 3, RetryDelay: time.Millisecond})
+
+	params := values(callback.Deposited, 100000)
+	if code := send(t, h, params); code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", code)
+	}
+	if got := rec.count(); got != 3 {
+		t.Errorf("handler calls = %d, want 3 attempts", got)
+	}
+	event, _ := parse(params)
+	if seen, _ := store.Seen(context.Background(), event.Key()); seen {
+		t.Fatal("failed callback was marked done")
+	}
+
+	// Повторная доставка шлюзом вызывает обработчик снова
+	rec.err = nil
+	if code := send(t, h, params); code != http.StatusOK {
+		t.Fatalf("redelivery: status = %d, want 200", code)
+	}
+	if got := rec.count(); got != 4 {
+		t.Errorf("handler calls = %d, want 4", got)
+	}
+	if seen, _ := store.Seen(context.Background(), event.Key()); !seen {
+		t.Error("delivered callback was not marked done")
+	}
+}
+
+func TestOnError(t *testing.T) {
+	var (
+		gotStatus int
+		gotErr    error
+	)
+	h := New(&fakeAPI{}, func(context.Context, Event) error { return nil }, Config{
+		OnError: func(_ *http.Request, status int, err error) {
+			gotStatus, gotErr = status, err
+		},
+	})
+
+	params := url.Values{"mdOrder": {"order-1"}, "operation": {"deposited"}, "status": {"1"}, "amount": {"abc"}}
+	if code := send(t, h, params); code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", code)
+	}
+	if gotStatus != http.StatusBadRequest || !errors.Is(gotErr, ErrInvalidCallback) {
+		t.Errorf("OnError(%d, %v), want 400 and ErrInvalidCallback", gotStatus, gotErr)
+	}
+}
+
+func TestMemoryStoreExpires(t *testing.T) {
+	store := NewMemoryStore(20 * time.Millisecond)
+	ctx := context.Background()
+	old := Key{OrderID: "order-1", Operation: types.CallbackDeposited, Success: true}
+	if err := store.MarkDone(ctx, old); err != nil {
+		t.Fatal(err)
+	}
+	if seen, _ := store.Seen(ctx, old); !seen {
+		t.Fatal("key is not seen right after MarkDone")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if seen, _ := store.Seen(ctx, old); seen {
+		t.Error("expired key is still seen")
+	}
+	store.MarkDone(ctx, Key{OrderID: "order-2", Operation: types.CallbackDeposited, Success: true})
+	if _, ok := store.done[old]; ok || len(store.done) != 1 {
+		t.Errorf("expired key was not pruned: %d keys", len(store.done))
+	}
+}